@@ -8,16 +8,25 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	"github.com/Congrool/nodes-grouping/cmd/controller-manager/app/options"
 	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	groupv1beta1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1"
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	policyv1beta1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1"
 	groupcontroller "github.com/Congrool/nodes-grouping/pkg/controllers/group"
+	overridecontroller "github.com/Congrool/nodes-grouping/pkg/controllers/override"
 	policycontroller "github.com/Congrool/nodes-grouping/pkg/controllers/policy"
+	nodegroupwebhook "github.com/Congrool/nodes-grouping/pkg/webhook/nodegroup"
+	overridepolicywebhook "github.com/Congrool/nodes-grouping/pkg/webhook/overridepolicy"
+	propagationpolicywebhook "github.com/Congrool/nodes-grouping/pkg/webhook/propagationpolicy"
 )
 
 // aggregatedScheme aggregates Kubernetes and extended schemems.
@@ -27,6 +36,8 @@ func init() {
 	utilruntime.Must(scheme.AddToScheme(aggregatedScheme))
 	utilruntime.Must(groupv1alpha1.AddToScheme(aggregatedScheme))
 	utilruntime.Must(policyv1alpha1.AddToScheme(aggregatedScheme))
+	utilruntime.Must(groupv1beta1.AddToScheme(aggregatedScheme))
+	utilruntime.Must(policyv1beta1.AddToScheme(aggregatedScheme))
 }
 
 // NewControllerManagerCommand creates a *cobra.Command object with default parameters
@@ -64,6 +75,8 @@ func Run(ctx context.Context, opts *options.Options) error {
 		LeaderElectionResourceLock: opts.LeaderElection.ResourceLock,
 		HealthProbeBindAddress:     net.JoinHostPort(opts.BindAddress, strconv.Itoa(opts.SecurePort)),
 		LivenessEndpointName:       "/healthz",
+		Port:                       opts.WebhookPort,
+		CertDir:                    opts.WebhookCertDir,
 	})
 	if err != nil {
 		klog.Errorf("failed to build controller manager: %v", err)
@@ -79,6 +92,14 @@ func Run(ctx context.Context, opts *options.Options) error {
 	klog.Infoln("execute Controllers")
 	setupControllers(controllerManager, opts, ctx.Done())
 
+	// CRDs are converted between versions by the manager, so the conversion
+	// webhook is always served.
+	setupConversionWebhook(controllerManager)
+	if opts.EnableWebhooks {
+		klog.Infoln("execute Webhooks")
+		setupWebhooks(controllerManager)
+	}
+
 	klog.Infoln("execute Start")
 	// blocks until the context is done
 	if err := controllerManager.Start(ctx); err != nil {
@@ -104,7 +125,14 @@ func setupControllers(mgr controllerruntime.Manager, opts *options.Options, stop
 	}
 
 	propagationPolicyController := &policycontroller.Controller{
-		Client: mgr.GetClient(),
+		Client:        mgr.GetClient(),
+		KubeClient:    kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		EventRecorder: mgr.GetEventRecorderFor(policycontroller.ControllerName),
+	}
+
+	overridePolicyController := &overridecontroller.Controller{
+		Client:        mgr.GetClient(),
+		EventRecorder: mgr.GetEventRecorderFor(overridecontroller.ControllerName),
 	}
 
 	klog.Infoln("setup nodegroup controller")
@@ -116,4 +144,25 @@ func setupControllers(mgr controllerruntime.Manager, opts *options.Options, stop
 	if err := propagationPolicyController.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Failed to setup propogation policy controller: %v", err)
 	}
+
+	klog.Infoln("setup overridepolicy controller")
+	if err := overridePolicyController.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Failed to setup override policy controller: %v", err)
+	}
+}
+
+// setupWebhooks registers admission webhooks to the webhook server of the manager.
+func setupWebhooks(mgr controllerruntime.Manager) {
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register("/mutate-propagationpolicy", &webhook.Admission{Handler: &propagationpolicywebhook.MutatingAdmission{}})
+	hookServer.Register("/validate-propagationpolicy", &webhook.Admission{Handler: &propagationpolicywebhook.ValidatingAdmission{Client: mgr.GetClient()}})
+	hookServer.Register("/mutate-overridepolicy", &webhook.Admission{Handler: &overridepolicywebhook.MutatingAdmission{}})
+	hookServer.Register("/validate-overridepolicy", &webhook.Admission{Handler: &overridepolicywebhook.ValidatingAdmission{Client: mgr.GetClient()}})
+	hookServer.Register("/validate-nodegroup", &webhook.Admission{Handler: &nodegroupwebhook.ValidatingAdmission{Client: mgr.GetClient()}})
+}
+
+// setupConversionWebhook registers the webhook converting NodeGroups and PropagationPolicies
+// between v1alpha1 and v1beta1 to the webhook server of the manager.
+func setupConversionWebhook(mgr controllerruntime.Manager) {
+	mgr.GetWebhookServer().Register("/convert", &conversion.Webhook{})
 }
@@ -9,6 +9,8 @@ import (
 const (
 	defaultBindAddress = "0.0.0.0"
 	defaultPort        = 10359
+	defaultWebhookPort = 9443
+	defaultCertDir     = "/tmp/k8s-webhook-server/serving-certs"
 )
 
 // Options contains everyting necessary to create and run controller-manager
@@ -23,6 +25,14 @@ type Options struct {
 	KubeAPIQPS float32
 	// KubeAPIBurst is the burst to allow whle talking with karmada-apiserver.
 	KubeAPIBurst int
+	// EnableWebhooks means the admission webhooks are served.
+	EnableWebhooks bool
+	// WebhookPort is the port that the webhook server serves at. The conversion webhook
+	// is always served, whether the admission webhooks are enabled or not.
+	WebhookPort int
+	// WebhookCertDir is the directory that contains the server key and certificate
+	// named tls.key and tls.crt.
+	WebhookCertDir string
 }
 
 //NewOptions builds an empty options
@@ -45,6 +55,9 @@ func (o *Options) AddFlags(flags *pflag.FlagSet) {
 		"The secure port on which to serve")
 	flags.BoolVar(&o.LeaderElection.LeaderElect, "leader-elect", true, "Start a leader election client and gain leadership before executing the main loop. Enable this when running replicated components for high availability.")
 	flags.StringVar(&o.LeaderElection.ResourceNamespace, "leader-elect-resource-namespace", "group-system", "The namespace of resource object that is used for locking during leader election.")
+	flags.BoolVar(&o.EnableWebhooks, "enable-webhooks", false, "Serve the admission webhooks defaulting and validating PropagationPolicy, OverridePolicy and NodeGroup.")
+	flags.IntVar(&o.WebhookPort, "webhook-port", defaultWebhookPort, "The port on which to serve the conversion webhook and the admission webhooks.")
+	flags.StringVar(&o.WebhookCertDir, "webhook-cert-dir", defaultCertDir, "The directory that contains the webhook server key and certificate, named tls.key and tls.crt.")
 	flags.Float32Var(&o.KubeAPIQPS, "kube-api-qps", 40.0, "QPS to use while talking with karmada-apiserver. Doesn't cover events and node heartbeat apis which rate limiting is controlled by a different set of flags.")
 	flags.IntVar(&o.KubeAPIBurst, "kube-api-burst", 60, "Burst to use while talking with karmada-apiserver. Doesn't cover events and node heartbeat apis which rate limiting is controlled by a different set of flags.")
 }
@@ -5,10 +5,17 @@ import (
 
 	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/generated/clientset/versioned"
 	"github.com/Congrool/nodes-grouping/pkg/schedulerextender"
+	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/cache"
+	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/constants"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,7 +43,7 @@ func main() {
 	config := ctrl.GetConfigOrDie()
 	defer klog.Flush()
 
-	client, err := client.New(config, client.Options{
+	apiClient, err := client.New(config, client.Options{
 		Scheme: scheme,
 	})
 
@@ -44,6 +51,29 @@ func main() {
 		klog.Fatalf("failed to get client, %v", err)
 	}
 
-	server := schedulerextender.NewPolicyServer(context.Background(), client)
+	kubeClient := kubernetes.NewForConfigOrDie(config)
+	groupingClient := versioned.NewForConfigOrDie(config)
+
+	ctx := context.Background()
+	extenderCache := cache.New(kubeClient, groupingClient, apiClient)
+	if err := extenderCache.Start(ctx.Done()); err != nil {
+		klog.Fatalf("failed to start cache, %v", err)
+	}
+	// read objects from the cache, and write them to the apiserver
+	client, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+		CacheReader: extenderCache.Reader(),
+		Client:      apiClient,
+	})
+	if err != nil {
+		klog.Fatalf("failed to get cached client, %v", err)
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	defer eventBroadcaster.Shutdown()
+	recorder := eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.ExtenderName})
+
+	server := schedulerextender.NewPolicyServer(ctx, client, recorder)
 	server.Run()
 }
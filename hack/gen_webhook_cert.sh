@@ -0,0 +1,70 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# This script creates a self-signed CA and a serving certificate of the webhook service, stores
+# them in the secret webhook-server-cert mounted by node-group-controller-manager, and sets the CA
+# as the caBundle of the conversion webhook of the CRDs and of the admission webhook configurations.
+# An existing secret is reused, so the script can be run again after the CRDs or the webhook
+# configurations are re-applied.
+# Requires kubectl, jq and openssl.
+
+NAMESPACE="group-system"
+SERVICE="webhook-service"
+SECRET="webhook-server-cert"
+CRDS=(
+  "nodegroups.group.kubeedge.io"
+  "propagationpolicies.policy.kubeedge.io"
+  "clusterpropagationpolicies.policy.kubeedge.io"
+)
+WEBHOOK_CONFIGURATIONS=(
+  "mutatingwebhookconfiguration/mutating-webhook-configuration"
+  "validatingwebhookconfiguration/validating-webhook-configuration"
+)
+
+if ! kubectl -n "${NAMESPACE}" get secret "${SECRET}" >/dev/null 2>&1; then
+  tmpdir=$(mktemp -d)
+  trap 'rm -rf "${tmpdir}"' EXIT
+
+  openssl req -x509 -newkey rsa:2048 -nodes -days 3650 -subj "/CN=${SERVICE}-ca" \
+    -keyout "${tmpdir}/ca.key" -out "${tmpdir}/ca.crt"
+  openssl req -newkey rsa:2048 -nodes -subj "/CN=${SERVICE}.${NAMESPACE}.svc" \
+    -keyout "${tmpdir}/tls.key" -out "${tmpdir}/tls.csr"
+  printf "subjectAltName=DNS:%s,DNS:%s,DNS:%s\n" "${SERVICE}.${NAMESPACE}.svc" \
+    "${SERVICE}.${NAMESPACE}.svc.cluster.local" "${SERVICE}.${NAMESPACE}" > "${tmpdir}/san.ext"
+  openssl x509 -req -days 3650 -in "${tmpdir}/tls.csr" -CA "${tmpdir}/ca.crt" -CAkey "${tmpdir}/ca.key" \
+    -CAcreateserial -extfile "${tmpdir}/san.ext" -out "${tmpdir}/tls.crt"
+
+  kubectl create namespace "${NAMESPACE}" --dry-run=client -o yaml | kubectl apply -f -
+  kubectl -n "${NAMESPACE}" create secret generic "${SECRET}" \
+    --from-file=tls.crt="${tmpdir}/tls.crt" --from-file=tls.key="${tmpdir}/tls.key" --from-file=ca.crt="${tmpdir}/ca.crt"
+  echo "secret ${NAMESPACE}/${SECRET} is created"
+fi
+
+ca_bundle=$(kubectl -n "${NAMESPACE}" get secret "${SECRET}" -o jsonpath='{.data.ca\.crt}')
+if [[ -z "${ca_bundle}" ]]; then
+  echo "secret ${NAMESPACE}/${SECRET} has no ca.crt, set caBundle of the webhooks manually"
+  exit 1
+fi
+
+for crd in "${CRDS[@]}"; do
+  if ! kubectl get crd "${crd}" >/dev/null 2>&1; then
+    echo "CRD ${crd} is not installed, skip it"
+    continue
+  fi
+  kubectl get crd "${crd}" -o json |
+    jq --arg ca "${ca_bundle}" '.spec.conversion.webhook.clientConfig.caBundle = $ca' |
+    kubectl replace -f -
+done
+
+for configuration in "${WEBHOOK_CONFIGURATIONS[@]}"; do
+  if ! kubectl get "${configuration}" >/dev/null 2>&1; then
+    echo "${configuration} is not deployed, skip it"
+    continue
+  fi
+  kubectl get "${configuration}" -o json |
+    jq --arg ca "${ca_bundle}" '.webhooks[].clientConfig.caBundle = $ca' |
+    kubectl replace -f -
+done
@@ -0,0 +1,41 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# This script migrates NodeGroups created with the namespace-scoped NodeGroup CRD
+# to the cluster-scoped one. The scope of a CRD cannot be changed in place, so the
+# NodeGroups are backed up, the CRD is recreated and the NodeGroups are restored
+# without their namespaces.
+# Requires kubectl and jq.
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+CRD_NAME="nodegroups.group.kubeedge.io"
+CRD_FILE="${REPO_ROOT}/config/crd/bases/group.kubeedge.io_nodegroups.yaml"
+BACKUP_FILE=${BACKUP_FILE:-"${REPO_ROOT}/_output/nodegroups-backup.json"}
+
+scope=$(kubectl get crd ${CRD_NAME} -o jsonpath='{.spec.scope}')
+if [[ "${scope}" != "Namespaced" ]]; then
+  echo "CRD ${CRD_NAME} is ${scope}-scoped, nothing to migrate"
+  exit 0
+fi
+
+mkdir -p "$(dirname "${BACKUP_FILE}")"
+kubectl get nodegroups.group.kubeedge.io --all-namespaces -o json > "${BACKUP_FILE}"
+echo "NodeGroups are backed up to ${BACKUP_FILE}"
+
+duplicated=$(jq -r '[.items[].metadata.name] | group_by(.) | map(select(length > 1) | .[0]) | .[]' "${BACKUP_FILE}")
+if [[ -n "${duplicated}" ]]; then
+  echo "NodeGroups with the same name exist in different namespaces, rename them before migration:"
+  echo "${duplicated}"
+  exit 1
+fi
+
+kubectl delete crd ${CRD_NAME}
+kubectl apply -f "${CRD_FILE}"
+kubectl wait --for condition=established --timeout=60s crd/${CRD_NAME}
+
+jq '.items |= map({apiVersion, kind, metadata: {name: .metadata.name, labels: .metadata.labels, annotations: .metadata.annotations}, spec})' \
+  "${BACKUP_FILE}" | kubectl apply -f -
+echo "NodeGroups are migrated to cluster scope"
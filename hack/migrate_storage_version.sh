@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# This script migrates stored NodeGroups, PropagationPolicies and ClusterPropagationPolicies
+# to the storage version v1beta1. Objects are stored in the version they were last written in,
+# so each object is rewritten without changes, which stores it in v1beta1 through the conversion
+# webhook. Then v1alpha1 is removed from the stored versions of the CRDs, after which v1alpha1
+# could be dropped from the CRDs.
+# The script can be run again if it fails on conflicts with concurrent writes.
+# Requires kubectl and jq, and CRDs applied with config/crd and the conversion webhook running.
+
+STORAGE_VERSION="v1beta1"
+RESOURCES=(
+  "nodegroups.group.kubeedge.io"
+  "propagationpolicies.policy.kubeedge.io"
+  "clusterpropagationpolicies.policy.kubeedge.io"
+)
+
+for resource in "${RESOURCES[@]}"; do
+  storage=$(kubectl get crd "${resource}" -o jsonpath='{.spec.versions[?(@.storage==true)].name}')
+  if [[ "${storage}" != "${STORAGE_VERSION}" ]]; then
+    echo "storage version of CRD ${resource} is ${storage}, apply config/crd before migration"
+    exit 1
+  fi
+
+  objects=$(kubectl get "${resource}" --all-namespaces -o json)
+  if [[ $(jq '.items | length' <<< "${objects}") -gt 0 ]]; then
+    kubectl replace -f - <<< "${objects}"
+  fi
+
+  kubectl get crd "${resource}" -o json | jq ".status.storedVersions = [\"${STORAGE_VERSION}\"]" |
+    kubectl replace --raw "/apis/apiextensions.k8s.io/v1/customresourcedefinitions/${resource}/status" -f -
+  echo "${resource} are migrated to ${STORAGE_VERSION}"
+done
@@ -26,3 +26,60 @@ register-gen \
   --input-dirs=./pkg/apis/policy/v1alpha1 \
   --output-package=./pkg/apis/policy/v1alpha1 \
   --output-file-base=zz_generated.register
+
+register-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-dirs=./pkg/apis/group/v1beta1 \
+  --output-package=./pkg/apis/group/v1beta1 \
+  --output-file-base=zz_generated.register
+
+register-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-dirs=./pkg/apis/policy/v1beta1 \
+  --output-package=./pkg/apis/policy/v1beta1 \
+  --output-file-base=zz_generated.register
+
+echo "Generating with conversion-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/conversion-gen
+conversion-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-dirs=github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1 \
+  --output-base="${REPO_ROOT}/_output/codegen" \
+  --output-file-base=zz_generated.conversion
+for group in group policy; do
+  mv "_output/codegen/github.com/Congrool/nodes-grouping/pkg/apis/${group}/v1alpha1/zz_generated.conversion.go" \
+    "pkg/apis/${group}/v1alpha1/zz_generated.conversion.go"
+done
+
+echo "Generating with client-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/client-gen
+client-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-base="" \
+  --input=github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1 \
+  --output-base="${REPO_ROOT}/_output/codegen" \
+  --output-package=github.com/Congrool/nodes-grouping/pkg/generated/clientset \
+  --clientset-name=versioned
+
+echo "Generating with lister-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/lister-gen
+lister-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-dirs=github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1 \
+  --output-base="${REPO_ROOT}/_output/codegen" \
+  --output-package=github.com/Congrool/nodes-grouping/pkg/generated/listers
+
+echo "Generating with informer-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/informer-gen
+informer-gen \
+  --go-header-file hack/boilerplate.go.txt \
+  --input-dirs=github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1,github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1,github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1 \
+  --versioned-clientset-package=github.com/Congrool/nodes-grouping/pkg/generated/clientset/versioned \
+  --listers-package=github.com/Congrool/nodes-grouping/pkg/generated/listers \
+  --output-base="${REPO_ROOT}/_output/codegen" \
+  --output-package=github.com/Congrool/nodes-grouping/pkg/generated/informers
+
+# generators write to the output base in GOPATH layout, move generated code into the repo
+rm -rf pkg/generated
+mv _output/codegen/github.com/Congrool/nodes-grouping/pkg/generated pkg/generated
+rm -rf _output/codegen
@@ -0,0 +1,21 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1"
+)
+
+var _ conversion.Convertible = &NodeGroup{}
+
+// ConvertTo converts the nodegroup to the hub version v1beta1.
+func (src *NodeGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.NodeGroup)
+	return Convert_v1alpha1_NodeGroup_To_v1beta1_NodeGroup(src, dst, nil)
+}
+
+// ConvertFrom converts the nodegroup from the hub version v1beta1.
+func (dst *NodeGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.NodeGroup)
+	return Convert_v1beta1_NodeGroup_To_v1alpha1_NodeGroup(src, dst, nil)
+}
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"math/rand"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+
+	"github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1"
+)
+
+func TestNodeGroupRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add to scheme, %v", err)
+	}
+	f := fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(rand.Int63()), serializer.NewCodecFactory(scheme))
+
+	for i := 0; i < 1000; i++ {
+		original := &NodeGroup{}
+		f.Fuzz(original)
+		hub := &v1beta1.NodeGroup{}
+		if err := original.ConvertTo(hub); err != nil {
+			t.Fatalf("failed to convert to v1beta1, %v", err)
+		}
+		restored := &NodeGroup{}
+		if err := restored.ConvertFrom(hub); err != nil {
+			t.Fatalf("failed to convert from v1beta1, %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("v1alpha1 nodegroup changed after round trip: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		original := &v1beta1.NodeGroup{}
+		f.Fuzz(original)
+		spoke := &NodeGroup{}
+		if err := spoke.ConvertFrom(original); err != nil {
+			t.Fatalf("failed to convert from v1beta1, %v", err)
+		}
+		restored := &v1beta1.NodeGroup{}
+		if err := spoke.ConvertTo(restored); err != nil {
+			t.Fatalf("failed to convert to v1beta1, %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("v1beta1 nodegroup changed after round trip: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+}
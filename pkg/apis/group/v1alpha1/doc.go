@@ -17,4 +17,5 @@ limitations under the License.
 // Package v1alpha1 contains API Schema definitions for the group v1alpha1 API group
 //+kubebuilder:object:generate=true
 //+groupName=group.kubeedge.io
+// +k8s:conversion-gen=github.com/Congrool/nodes-grouping/pkg/apis/group/v1beta1
 package v1alpha1
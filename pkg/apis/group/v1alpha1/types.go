@@ -17,21 +17,90 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
-// NodeGroupSpec defines the desired state of NodeGroup
+// NodeGroupSpec defines the desired state of NodeGroup.
+// The nodegroup contains the union of nodes listed in Nodes and nodes matched by
+// all of MatchLabels, LabelSelector and MatchTaints that are specified.
+// If none of MatchLabels, LabelSelector and MatchTaints is specified, only nodes
+// listed in Nodes belong to the nodegroup. Nodes of ChildGroups, transitively, also
+// belong to the nodegroup.
 type NodeGroupSpec struct {
-	// Nodes contains names of all the nodes in the nodegroup.
+	// Nodes contains names of the nodes explicitly added to the nodegroup.
 	// +optional
 	Nodes []string `json:"nodes,omitempty"`
 
-	// MatchLabels match the nodes that have the labels
+	// MatchLabels match the nodes that have the labels.
 	// +optional
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// LabelSelector is a label query over nodes, which supports set-based requirements
+	// such as In, NotIn, Exists and DoesNotExist.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// MatchTaints match the nodes that have all the taints.
+	// +optional
+	MatchTaints []TaintSelector `json:"matchTaints,omitempty"`
+
+	// Exclusive means nodes of the nodegroup cannot be shared with other nodegroups.
+	// A node matched by an exclusive nodegroup only belongs to it, even if it is also
+	// matched by other non-exclusive nodegroups. Exclusive nodegroups must not overlap
+	// with each other, otherwise the earliest created one owns the conflicting nodes.
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
+
+	// LabelNodes means the label "group.kubeedge.io/nodegroup=<name>" will be added to
+	// nodes of the nodegroup, and removed once the node leaves the nodegroup.
+	// A node already labeled by another nodegroup will not be relabeled.
+	// +optional
+	LabelNodes bool `json:"labelNodes,omitempty"`
+
+	// NodeTaints will be added to nodes of the nodegroup, and removed once the node
+	// leaves the nodegroup. Taints with the same key and effect that nodes already have
+	// are never overwritten or removed.
+	// +optional
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+
+	// ChildGroups are nodegroups nested in the nodegroup, such as sites in a region.
+	// All nodes of child nodegroups also belong to the nodegroup, even if they are
+	// owned by an exclusive child nodegroup.
+	// +optional
+	ChildGroups []ChildNodeGroup `json:"childGroups,omitempty"`
+}
+
+// ChildNodeGroup references a child nodegroup.
+type ChildNodeGroup struct {
+	// Name is the name of the child nodegroup.
+	// +required
+	Name string `json:"name"`
+
+	// Weight is the preference to the child nodegroup when pods placed in the
+	// nodegroup are split across its child nodegroups.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// TaintSelector selects nodes with a matching taint.
+type TaintSelector struct {
+	// Key of the taint.
+	// +required
+	Key string `json:"key"`
+
+	// Value of the taint. Empty value matches any value.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect of the taint. Empty effect matches any effect.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
 }
 
 // NodeGroupStatus defines the observed state of NodeGroup
@@ -39,13 +108,83 @@ type NodeGroupStatus struct {
 	// ContainedNodes represents names of all nodes the nodegroup contains.
 	// +optional
 	ContainedNodes []string `json:"containedNodes,omitempty"`
+
+	// MissingNodes represents names of nodes listed in Spec.Nodes which do not exist in the cluster.
+	// +optional
+	MissingNodes []string `json:"missingNodes,omitempty"`
+
+	// TotalNodes is the number of nodes the nodegroup contains.
+	// +optional
+	TotalNodes int32 `json:"totalNodes,omitempty"`
+
+	// ReadyNodes is the number of nodes in the nodegroup whose Ready condition is true.
+	// +optional
+	ReadyNodes int32 `json:"readyNodes,omitempty"`
+
+	// NotReadyNodes is the number of nodes in the nodegroup whose Ready condition is not true.
+	// +optional
+	NotReadyNodes int32 `json:"notReadyNodes,omitempty"`
+
+	// UnschedulableNodes is the number of nodes in the nodegroup which are marked as unschedulable.
+	// +optional
+	UnschedulableNodes int32 `json:"unschedulableNodes,omitempty"`
+
+	// Allocatable is the sum of allocatable cpu, memory and pods of all nodes in the nodegroup.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+
+	// Requested is the sum of cpu and memory requested by pods running in the nodegroup,
+	// along with the number of these pods.
+	// +optional
+	Requested corev1.ResourceList `json:"requested,omitempty"`
+
+	// AppliedTaints represents the taints that have been added to nodes of the nodegroup.
+	// Taints added to each node are recorded in NodeGroupTaintsAnnotation of the node.
+	// +optional
+	AppliedTaints []corev1.Taint `json:"appliedTaints,omitempty"`
+
+	// Conditions contain the different condition statuses of the nodegroup.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
-//+kubebuilder:resource:scope="Cluster"
+const (
+	// NodeGroupLabel is the label added to nodes of nodegroups with LabelNodes enabled,
+	// whose value is the name of the nodegroup.
+	NodeGroupLabel = "group.kubeedge.io/nodegroup"
+
+	// NodeGroupTaintsAnnotation is the annotation on nodes recording the taints added to them by
+	// nodegroups, in the form of a JSON object from names of nodegroups to their taints.
+	NodeGroupTaintsAnnotation = "group.kubeedge.io/applied-taints"
+)
+
+// These are valid conditions of a nodegroup.
+const (
+	// NodeGroupReady means at least one node in the nodegroup is ready and schedulable.
+	NodeGroupReady = "Ready"
+
+	// NodeGroupDegraded means some nodes in the nodegroup are not ready or unschedulable.
+	NodeGroupDegraded = "Degraded"
+
+	// NodeGroupOffline means the nodegroup contains nodes but none of them is ready. Its
+	// LastTransitionTime is when the last ready node of the nodegroup became not ready.
+	NodeGroupOffline = "Offline"
+
+	// NodeGroupOverlapped means some nodes matched by the nodegroup are also matched by other nodegroups.
+	NodeGroupOverlapped = "Overlapped"
+
+	// NodeGroupDeletionBlocked means the nodegroup is being deleted but still referenced by policies.
+	NodeGroupDeletionBlocked = "DeletionBlocked"
+)
+
+// +genclient
+// +genclient:nonNamespaced
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-//+kubebuilder:storageversion
-//+kubebuilder:resource:shortName=ng
+//+kubebuilder:resource:scope=Cluster,shortName=ng
+//+kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.totalNodes"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyNodes"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // NodeGroup is the Schema for the nodegroups API
 type NodeGroup struct {
@@ -21,9 +21,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChildNodeGroup) DeepCopyInto(out *ChildNodeGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildNodeGroup.
+func (in *ChildNodeGroup) DeepCopy() *ChildNodeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ChildNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 	*out = *in
@@ -98,6 +115,28 @@ func (in *NodeGroupSpec) DeepCopyInto(out *NodeGroupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchTaints != nil {
+		in, out := &in.MatchTaints, &out.MatchTaints
+		*out = make([]TaintSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ChildGroups != nil {
+		in, out := &in.ChildGroups, &out.ChildGroups
+		*out = make([]ChildNodeGroup, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupSpec.
@@ -118,6 +157,39 @@ func (in *NodeGroupStatus) DeepCopyInto(out *NodeGroupStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MissingNodes != nil {
+		in, out := &in.MissingNodes, &out.MissingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AppliedTaints != nil {
+		in, out := &in.AppliedTaints, &out.AppliedTaints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupStatus.
@@ -129,3 +201,18 @@ func (in *NodeGroupStatus) DeepCopy() *NodeGroupStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaintSelector) DeepCopyInto(out *TaintSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaintSelector.
+func (in *TaintSelector) DeepCopy() *TaintSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TaintSelector)
+	in.DeepCopyInto(out)
+	return out
+}
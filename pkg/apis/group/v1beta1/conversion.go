@@ -0,0 +1,4 @@
+package v1beta1
+
+// Hub marks NodeGroup of v1beta1 as the version other versions are converted to and from.
+func (*NodeGroup) Hub() {}
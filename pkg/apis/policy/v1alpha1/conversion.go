@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1"
+)
+
+// StaticWeightListAnnotation preserves the StaticWeightList of a policy converted to v1beta1 if it
+// has entries without exactly one nodegroup name, which v1beta1 cannot represent. The list is
+// restored when the policy is converted back, unless the placement is changed in v1beta1.
+const StaticWeightListAnnotation = "policy.kubeedge.io/v1alpha1-static-weight-list"
+
+var _ ctrlconversion.Convertible = &PropagationPolicy{}
+var _ ctrlconversion.Convertible = &ClusterPropagationPolicy{}
+
+// ConvertTo converts the policy to the hub version v1beta1.
+func (src *PropagationPolicy) ConvertTo(dstRaw ctrlconversion.Hub) error {
+	dst := dstRaw.(*v1beta1.PropagationPolicy)
+	if err := Convert_v1alpha1_PropagationPolicy_To_v1beta1_PropagationPolicy(src, dst, nil); err != nil {
+		return err
+	}
+	return preserveStaticWeightList(src.Spec.Placement.StaticWeightList, &dst.ObjectMeta)
+}
+
+// ConvertFrom converts the policy from the hub version v1beta1.
+func (dst *PropagationPolicy) ConvertFrom(srcRaw ctrlconversion.Hub) error {
+	src := srcRaw.(*v1beta1.PropagationPolicy)
+	if err := Convert_v1beta1_PropagationPolicy_To_v1alpha1_PropagationPolicy(src, dst, nil); err != nil {
+		return err
+	}
+	restoreStaticWeightList(&dst.ObjectMeta, &dst.Spec.Placement)
+	return nil
+}
+
+// ConvertTo converts the policy to the hub version v1beta1.
+func (src *ClusterPropagationPolicy) ConvertTo(dstRaw ctrlconversion.Hub) error {
+	dst := dstRaw.(*v1beta1.ClusterPropagationPolicy)
+	if err := Convert_v1alpha1_ClusterPropagationPolicy_To_v1beta1_ClusterPropagationPolicy(src, dst, nil); err != nil {
+		return err
+	}
+	return preserveStaticWeightList(src.Spec.Placement.StaticWeightList, &dst.ObjectMeta)
+}
+
+// ConvertFrom converts the policy from the hub version v1beta1.
+func (dst *ClusterPropagationPolicy) ConvertFrom(srcRaw ctrlconversion.Hub) error {
+	src := srcRaw.(*v1beta1.ClusterPropagationPolicy)
+	if err := Convert_v1beta1_ClusterPropagationPolicy_To_v1alpha1_ClusterPropagationPolicy(src, dst, nil); err != nil {
+		return err
+	}
+	restoreStaticWeightList(&dst.ObjectMeta, &dst.Spec.Placement)
+	return nil
+}
+
+// Convert_v1alpha1_NodeGroupPreferences_To_v1beta1_Placement converts each entry of StaticWeightList
+// to a nodegroup with its first name, which is the only one of the entry getting pods.
+func Convert_v1alpha1_NodeGroupPreferences_To_v1beta1_Placement(in *NodeGroupPreferences, out *v1beta1.Placement, s conversion.Scope) error {
+	out.NodeGroups = nil
+	for _, weight := range in.StaticWeightList {
+		if len(weight.NodeGroupNames) == 0 {
+			continue
+		}
+		out.NodeGroups = append(out.NodeGroups, v1beta1.NodeGroupWeight{
+			Name:   weight.NodeGroupNames[0],
+			Weight: weight.Weight,
+		})
+	}
+	out.SplitByChildGroups = in.SplitByChildGroups
+	return nil
+}
+
+// Convert_v1beta1_Placement_To_v1alpha1_NodeGroupPreferences converts each nodegroup to an entry
+// of StaticWeightList with a single name.
+func Convert_v1beta1_Placement_To_v1alpha1_NodeGroupPreferences(in *v1beta1.Placement, out *NodeGroupPreferences, s conversion.Scope) error {
+	out.StaticWeightList = nil
+	for _, group := range in.NodeGroups {
+		out.StaticWeightList = append(out.StaticWeightList, StaticNodeGroupWeight{
+			NodeGroupNames: []string{group.Name},
+			Weight:         group.Weight,
+		})
+	}
+	out.SplitByChildGroups = in.SplitByChildGroups
+	return nil
+}
+
+// preserveStaticWeightList sets StaticWeightListAnnotation to the weights if v1beta1 cannot represent them.
+func preserveStaticWeightList(weights []StaticNodeGroupWeight, meta *metav1.ObjectMeta) error {
+	representable := true
+	for _, weight := range weights {
+		if len(weight.NodeGroupNames) != 1 {
+			representable = false
+			break
+		}
+	}
+	if representable {
+		return nil
+	}
+
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return err
+	}
+	// the annotations are shared with the converted object
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	for key, value := range meta.Annotations {
+		annotations[key] = value
+	}
+	annotations[StaticWeightListAnnotation] = string(data)
+	meta.Annotations = annotations
+	return nil
+}
+
+// restoreStaticWeightList removes StaticWeightListAnnotation, and restores the weights it preserves
+// if they are still converted to the nodegroups of the placement.
+func restoreStaticWeightList(meta *metav1.ObjectMeta, placement *NodeGroupPreferences) {
+	data, ok := meta.Annotations[StaticWeightListAnnotation]
+	if !ok {
+		return
+	}
+	annotations := make(map[string]string, len(meta.Annotations)-1)
+	for key, value := range meta.Annotations {
+		if key != StaticWeightListAnnotation {
+			annotations[key] = value
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+
+	weights := []StaticNodeGroupWeight{}
+	if err := json.Unmarshal([]byte(data), &weights); err != nil {
+		return
+	}
+	preserved, current := &v1beta1.Placement{}, &v1beta1.Placement{}
+	_ = Convert_v1alpha1_NodeGroupPreferences_To_v1beta1_Placement(&NodeGroupPreferences{StaticWeightList: weights}, preserved, nil)
+	_ = Convert_v1alpha1_NodeGroupPreferences_To_v1beta1_Placement(placement, current, nil)
+	if equality.Semantic.DeepEqual(preserved.NodeGroups, current.NodeGroups) {
+		placement.StaticWeightList = weights
+	}
+}
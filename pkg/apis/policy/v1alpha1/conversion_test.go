@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"math/rand"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1"
+)
+
+func TestPropagationPolicyRoundTrip(t *testing.T) {
+	testRoundTrip(t, &PropagationPolicy{}, &v1beta1.PropagationPolicy{})
+}
+
+func TestClusterPropagationPolicyRoundTrip(t *testing.T) {
+	testRoundTrip(t, &ClusterPropagationPolicy{}, &v1beta1.ClusterPropagationPolicy{})
+}
+
+func TestConvertStaticWeightList(t *testing.T) {
+	policy := &PropagationPolicy{
+		Spec: PropagationPolicySpec{
+			Placement: NodeGroupPreferences{
+				StaticWeightList: []StaticNodeGroupWeight{
+					{NodeGroupNames: []string{"hangzhou", "ningbo"}, Weight: 2},
+					{NodeGroupNames: []string{"beijing"}, Weight: 1},
+				},
+			},
+		},
+	}
+	hub := &v1beta1.PropagationPolicy{}
+	if err := policy.ConvertTo(hub); err != nil {
+		t.Fatalf("failed to convert to v1beta1, %v", err)
+	}
+	want := []v1beta1.NodeGroupWeight{{Name: "hangzhou", Weight: 2}, {Name: "beijing", Weight: 1}}
+	if !equality.Semantic.DeepEqual(hub.Spec.Placement.NodeGroups, want) {
+		t.Errorf("want nodegroups %v, but get %v", want, hub.Spec.Placement.NodeGroups)
+	}
+	if _, ok := hub.Annotations[StaticWeightListAnnotation]; !ok {
+		t.Errorf("want annotation %s preserving the static weight list", StaticWeightListAnnotation)
+	}
+
+	// the preserved list is dropped once the placement is changed in v1beta1
+	hub.Spec.Placement.NodeGroups[0].Weight = 3
+	converted := &PropagationPolicy{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert from v1beta1, %v", err)
+	}
+	wantWeights := []StaticNodeGroupWeight{
+		{NodeGroupNames: []string{"hangzhou"}, Weight: 3},
+		{NodeGroupNames: []string{"beijing"}, Weight: 1},
+	}
+	if !equality.Semantic.DeepEqual(converted.Spec.Placement.StaticWeightList, wantWeights) {
+		t.Errorf("want static weight list %v, but get %v", wantWeights, converted.Spec.Placement.StaticWeightList)
+	}
+	if len(converted.Annotations) != 0 {
+		t.Errorf("want no annotations, but get %v", converted.Annotations)
+	}
+}
+
+// testRoundTrip fuzzes objects of both versions and checks that they are unchanged after
+// being converted to the other version and back.
+func testRoundTrip(t *testing.T, spoke ctrlconversion.Convertible, hub ctrlconversion.Hub) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add to scheme, %v", err)
+	}
+	f := fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(rand.Int63()), serializer.NewCodecFactory(scheme))
+
+	for i := 0; i < 1000; i++ {
+		original := spoke.DeepCopyObject().(ctrlconversion.Convertible)
+		f.Fuzz(original)
+		converted := hub.DeepCopyObject().(ctrlconversion.Hub)
+		if err := original.ConvertTo(converted); err != nil {
+			t.Fatalf("failed to convert to hub, %v", err)
+		}
+		restored := spoke.DeepCopyObject().(ctrlconversion.Convertible)
+		if err := restored.ConvertFrom(converted); err != nil {
+			t.Fatalf("failed to convert from hub, %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("spoke changed after round trip: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		original := hub.DeepCopyObject().(ctrlconversion.Hub)
+		f.Fuzz(original)
+		converted := spoke.DeepCopyObject().(ctrlconversion.Convertible)
+		if err := converted.ConvertFrom(original); err != nil {
+			t.Fatalf("failed to convert from hub, %v", err)
+		}
+		restored := hub.DeepCopyObject().(ctrlconversion.Hub)
+		if err := converted.ConvertTo(restored); err != nil {
+			t.Fatalf("failed to convert to hub, %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("hub changed after round trip: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// AllNodeGroups in TargetNodeGroup of an override rule matches all nodegroups.
+	AllNodeGroups = "*"
+
+	// DefaultNodeGroupWeight is the default weight of a StaticNodeGroupWeight.
+	DefaultNodeGroupWeight int64 = 1
+	// DefaultRebalanceStrategyType is the default type of a RebalanceStrategy.
+	DefaultRebalanceStrategyType = EvictRebalanceStrategy
+)
+
+// DefaultMaxUnavailable is the default MaxUnavailable of a RebalanceStrategy.
+var DefaultMaxUnavailable = intstr.FromInt(1)
+
+// SetDefaultsPropagationPolicy sets defaults of the PropagationPolicy which are not set. Policies
+// are defaulted when admitted, and again by their consumers in case the webhook is not enabled.
+func SetDefaultsPropagationPolicy(policy *PropagationPolicy) {
+	setDefaultsPropagationPolicySpec(&policy.Spec, policy.Namespace)
+}
+
+// SetDefaultsClusterPropagationPolicy sets defaults of the ClusterPropagationPolicy which are not set.
+func SetDefaultsClusterPropagationPolicy(policy *ClusterPropagationPolicy) {
+	setDefaultsPropagationPolicySpec(&policy.Spec, "")
+}
+
+func setDefaultsPropagationPolicySpec(spec *PropagationPolicySpec, namespace string) {
+	setDefaultsResourceSelectors(spec.ResourceSelectors, namespace)
+	for i := range spec.Placement.StaticWeightList {
+		if spec.Placement.StaticWeightList[i].Weight == 0 {
+			spec.Placement.StaticWeightList[i].Weight = DefaultNodeGroupWeight
+		}
+	}
+	if spec.Rebalance.Type == "" {
+		spec.Rebalance.Type = DefaultRebalanceStrategyType
+	}
+	if spec.Rebalance.MaxUnavailable == nil {
+		maxUnavailable := DefaultMaxUnavailable
+		spec.Rebalance.MaxUnavailable = &maxUnavailable
+	}
+}
+
+// SetDefaultsOverridePolicy sets defaults of the OverridePolicy which are not set.
+func SetDefaultsOverridePolicy(policy *OverridePolicy) {
+	setDefaultsOverrideSpec(&policy.Spec, policy.Namespace)
+}
+
+// SetDefaultsClusterOverridePolicy sets defaults of the ClusterOverridePolicy which are not set.
+func SetDefaultsClusterOverridePolicy(policy *ClusterOverridePolicy) {
+	setDefaultsOverrideSpec(&policy.Spec, "")
+}
+
+func setDefaultsOverrideSpec(spec *OverrideSpec, namespace string) {
+	setDefaultsResourceSelectors(spec.ResourceSelectors, namespace)
+	for i := range spec.OverrideRules {
+		if len(spec.OverrideRules[i].TargetNodeGroup) == 0 {
+			spec.OverrideRules[i].TargetNodeGroup = []string{AllNodeGroups}
+		}
+	}
+}
+
+// setDefaultsResourceSelectors sets namespaces of resource selectors to the namespace of the
+// policy. Resource selectors of cluster-scoped policies are left unchanged.
+func setDefaultsResourceSelectors(selectors []ResourceSelector, namespace string) {
+	if namespace == "" {
+		return
+	}
+	for i := range selectors {
+		if selectors[i].Namespace == "" {
+			selectors[i].Namespace = namespace
+		}
+	}
+}
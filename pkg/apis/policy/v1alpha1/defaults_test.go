@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSetDefaultsPropagationPolicy(t *testing.T) {
+	maxUnavailable := intstr.FromString("50%")
+	defaultMaxUnavailable := DefaultMaxUnavailable
+
+	cases := []struct {
+		name string
+		spec PropagationPolicySpec
+		want PropagationPolicySpec
+	}{
+		{
+			name: "empty spec",
+			want: PropagationPolicySpec{
+				Rebalance: RebalanceStrategy{Type: EvictRebalanceStrategy, MaxUnavailable: &defaultMaxUnavailable},
+			},
+		},
+		{
+			name: "namespaces of resource selectors",
+			spec: PropagationPolicySpec{
+				ResourceSelectors: []ResourceSelector{
+					{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy"},
+					{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "other", Name: "deploy"},
+				},
+			},
+			want: PropagationPolicySpec{
+				ResourceSelectors: []ResourceSelector{
+					{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "deploy"},
+					{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "other", Name: "deploy"},
+				},
+				Rebalance: RebalanceStrategy{Type: EvictRebalanceStrategy, MaxUnavailable: &defaultMaxUnavailable},
+			},
+		},
+		{
+			name: "weights of nodegroups",
+			spec: PropagationPolicySpec{
+				Placement: NodeGroupPreferences{
+					StaticWeightList: []StaticNodeGroupWeight{
+						{NodeGroupNames: []string{"beijing"}},
+						{NodeGroupNames: []string{"hangzhou"}, Weight: 3},
+					},
+				},
+			},
+			want: PropagationPolicySpec{
+				Placement: NodeGroupPreferences{
+					StaticWeightList: []StaticNodeGroupWeight{
+						{NodeGroupNames: []string{"beijing"}, Weight: DefaultNodeGroupWeight},
+						{NodeGroupNames: []string{"hangzhou"}, Weight: 3},
+					},
+				},
+				Rebalance: RebalanceStrategy{Type: EvictRebalanceStrategy, MaxUnavailable: &defaultMaxUnavailable},
+			},
+		},
+		{
+			name: "rebalance strategy set",
+			spec: PropagationPolicySpec{
+				Rebalance: RebalanceStrategy{Type: DeleteRebalanceStrategy, MaxUnavailable: &maxUnavailable},
+			},
+			want: PropagationPolicySpec{
+				Rebalance: RebalanceStrategy{Type: DeleteRebalanceStrategy, MaxUnavailable: &maxUnavailable},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := &PropagationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+				Spec:       *c.spec.DeepCopy(),
+			}
+			SetDefaultsPropagationPolicy(policy)
+			if !equality.Semantic.DeepEqual(policy.Spec, c.want) {
+				t.Errorf("want spec %+v, got %+v", c.want, policy.Spec)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsClusterPropagationPolicy(t *testing.T) {
+	policy := &ClusterPropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec: PropagationPolicySpec{
+			ResourceSelectors: []ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy"}},
+		},
+	}
+	SetDefaultsClusterPropagationPolicy(policy)
+	// selectors of cluster-scoped policies select all namespaces
+	if namespace := policy.Spec.ResourceSelectors[0].Namespace; namespace != "" {
+		t.Errorf("want empty namespace, got %q", namespace)
+	}
+	if policy.Spec.Rebalance.Type != DefaultRebalanceStrategyType {
+		t.Errorf("want rebalance type %s, got %s", DefaultRebalanceStrategyType, policy.Spec.Rebalance.Type)
+	}
+}
+
+func TestSetDefaultsOverridePolicy(t *testing.T) {
+	cases := []struct {
+		name string
+		spec OverrideSpec
+		want OverrideSpec
+	}{
+		{
+			name: "target nodegroups of rules",
+			spec: OverrideSpec{
+				OverrideRules: []RuleWithNodeGroup{
+					{},
+					{TargetNodeGroup: []string{"beijing"}},
+				},
+			},
+			want: OverrideSpec{
+				OverrideRules: []RuleWithNodeGroup{
+					{TargetNodeGroup: []string{AllNodeGroups}},
+					{TargetNodeGroup: []string{"beijing"}},
+				},
+			},
+		},
+		{
+			name: "namespaces of resource selectors",
+			spec: OverrideSpec{
+				ResourceSelectors: []ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy"}},
+			},
+			want: OverrideSpec{
+				ResourceSelectors: []ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "deploy"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := &OverridePolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+				Spec:       *c.spec.DeepCopy(),
+			}
+			SetDefaultsOverridePolicy(policy)
+			if !equality.Semantic.DeepEqual(policy.Spec, c.want) {
+				t.Errorf("want spec %+v, got %+v", c.want, policy.Spec)
+			}
+		})
+	}
+}
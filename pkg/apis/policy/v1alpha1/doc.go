@@ -17,4 +17,5 @@ limitations under the License.
 // Package v1alpha1 contains API Schema definitions for the policy v1alpha1 API group
 //+kubebuilder:object:generate=true
 //+groupName=policy.kubeedge.io
+// +k8s:conversion-gen=github.com/Congrool/nodes-grouping/pkg/apis/policy/v1beta1
 package v1alpha1
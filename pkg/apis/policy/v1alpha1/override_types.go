@@ -7,15 +7,64 @@ import (
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-// +kubebuilder:resource:shortName=op
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=op
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // OverridePolicy represents the policy that overrides a group of resources to one or more nodegroups.
+// Overriders of an OverridePolicy take precedence over conflicting ones of ClusterOverridePolicies.
 type OverridePolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// Spec represents the desired behavior of OverridePolicy.
 	Spec OverrideSpec `json:"spec"`
+
+	// Status represents the observed state of OverridePolicy.
+	// +optional
+	Status OverridePolicyStatus `json:"status,omitempty"`
+}
+
+// OverridePolicyStatus defines the observed state of OverridePolicy and ClusterOverridePolicy.
+type OverridePolicyStatus struct {
+	// ObservedGeneration is the generation of the policy the status is computed from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// AppliedResources are the existing resources selected by the policy.
+	// +optional
+	AppliedResources []ResourceReference `json:"appliedResources,omitempty"`
+
+	// Conflicts are overriders of the policy which are shadowed on a resource by
+	// conflicting overriders of policies with higher precedence.
+	// +optional
+	Conflicts []OverrideConflict `json:"conflicts,omitempty"`
+}
+
+// ResourceReference references a resource.
+type ResourceReference struct {
+	// APIVersion of the resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the resource.
+	Name string `json:"name"`
+}
+
+// OverrideConflict describes an overrider shadowed on a resource.
+type OverrideConflict struct {
+	// Resource is the resource the overrider is shadowed on.
+	Resource ResourceReference `json:"resource"`
+	// Overrider identifies the shadowed overrider, such as "plaintext /spec/replicas".
+	Overrider string `json:"overrider"`
+	// OverriddenBy is the policy whose overrider takes effect instead,
+	// such as "OverridePolicy default/foo".
+	OverriddenBy string `json:"overriddenBy"`
 }
 
 // OverrideSpec defines the desired behavior of OverridePolicy.
@@ -34,7 +83,7 @@ type OverrideSpec struct {
 type RuleWithNodeGroup struct {
 	// TargetNodeGroup defines restrictions on this override policy
 	// that only applies to resources propagated to the matching nodegroups.
-	// nil means matching all nodegroups.
+	// ["*"] means matching all nodegroups, which is the default.
 	// +optional
 	TargetNodeGroup []string `json:"targetNodeGroup,omitempty"`
 
@@ -177,6 +226,7 @@ const (
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
 
 // OverridePolicyList is a collection of OverridePolicy.
 type OverridePolicyList struct {
@@ -186,3 +236,38 @@ type OverridePolicyList struct {
 	// Items holds a list of OverridePolicy.
 	Items []OverridePolicy `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster,shortName=cop
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterOverridePolicy represents the cluster-wide policy that overrides a group of resources
+// to one or more nodegroups. Its resource selectors must specify the namespace.
+type ClusterOverridePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec represents the desired behavior of ClusterOverridePolicy.
+	Spec OverrideSpec `json:"spec"`
+
+	// Status represents the observed state of ClusterOverridePolicy.
+	// +optional
+	Status OverridePolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+
+// ClusterOverridePolicyList is a collection of ClusterOverridePolicy.
+type ClusterOverridePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items holds a list of ClusterOverridePolicy.
+	Items []ClusterOverridePolicy `json:"items"`
+}
@@ -23,8 +23,127 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOverridePolicy) DeepCopyInto(out *ClusterOverridePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOverridePolicy.
+func (in *ClusterOverridePolicy) DeepCopy() *ClusterOverridePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOverridePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOverridePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOverridePolicyList) DeepCopyInto(out *ClusterOverridePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOverridePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOverridePolicyList.
+func (in *ClusterOverridePolicyList) DeepCopy() *ClusterOverridePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOverridePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOverridePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPropagationPolicy) DeepCopyInto(out *ClusterPropagationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPropagationPolicy.
+func (in *ClusterPropagationPolicy) DeepCopy() *ClusterPropagationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPropagationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPropagationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPropagationPolicyList) DeepCopyInto(out *ClusterPropagationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPropagationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPropagationPolicyList.
+func (in *ClusterPropagationPolicyList) DeepCopy() *ClusterPropagationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPropagationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPropagationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommandArgsOverrider) DeepCopyInto(out *CommandArgsOverrider) {
 	*out = *in
@@ -45,6 +164,47 @@ func (in *CommandArgsOverrider) DeepCopy() *CommandArgsOverrider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedOverNodeGroup) DeepCopyInto(out *FailedOverNodeGroup) {
+	*out = *in
+	in.FailedOverTime.DeepCopyInto(&out.FailedOverTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedOverNodeGroup.
+func (in *FailedOverNodeGroup) DeepCopy() *FailedOverNodeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedOverNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverPolicy) DeepCopyInto(out *FailoverPolicy) {
+	*out = *in
+	if in.TolerationSeconds != nil {
+		in, out := &in.TolerationSeconds, &out.TolerationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecoverySeconds != nil {
+		in, out := &in.RecoverySeconds, &out.RecoverySeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverPolicy.
+func (in *FailoverPolicy) DeepCopy() *FailoverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageOverrider) DeepCopyInto(out *ImageOverrider) {
 	*out = *in
@@ -80,6 +240,21 @@ func (in *ImagePredicate) DeepCopy() *ImagePredicate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupPodsStatus) DeepCopyInto(out *NodeGroupPodsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupPodsStatus.
+func (in *NodeGroupPodsStatus) DeepCopy() *NodeGroupPodsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupPodsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupPreferences) DeepCopyInto(out *NodeGroupPreferences) {
 	*out = *in
@@ -102,12 +277,29 @@ func (in *NodeGroupPreferences) DeepCopy() *NodeGroupPreferences {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideConflict) DeepCopyInto(out *OverrideConflict) {
+	*out = *in
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideConflict.
+func (in *OverrideConflict) DeepCopy() *OverrideConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(OverrideConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OverridePolicy) DeepCopyInto(out *OverridePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverridePolicy.
@@ -160,6 +352,31 @@ func (in *OverridePolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverridePolicyStatus) DeepCopyInto(out *OverridePolicyStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]ResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]OverrideConflict, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverridePolicyStatus.
+func (in *OverridePolicyStatus) DeepCopy() *OverridePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OverridePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 	*out = *in
@@ -254,6 +471,7 @@ func (in *PropagationPolicy) DeepCopyInto(out *PropagationPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationPolicy.
@@ -317,6 +535,9 @@ func (in *PropagationPolicySpec) DeepCopyInto(out *PropagationPolicySpec) {
 		}
 	}
 	in.Placement.DeepCopyInto(&out.Placement)
+	in.Rebalance.DeepCopyInto(&out.Rebalance)
+	in.Failover.DeepCopyInto(&out.Failover)
+	out.Spillover = in.Spillover
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationPolicySpec.
@@ -332,6 +553,27 @@ func (in *PropagationPolicySpec) DeepCopy() *PropagationPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropagationPolicyStatus) DeepCopyInto(out *PropagationPolicyStatus) {
 	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadPlacementStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedOverNodeGroups != nil {
+		in, out := &in.FailedOverNodeGroups, &out.FailedOverNodeGroups
+		*out = make([]FailedOverNodeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationPolicyStatus.
@@ -344,6 +586,41 @@ func (in *PropagationPolicyStatus) DeepCopy() *PropagationPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceStrategy) DeepCopyInto(out *RebalanceStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceStrategy.
+func (in *RebalanceStrategy) DeepCopy() *RebalanceStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
 	*out = *in
@@ -385,6 +662,21 @@ func (in *RuleWithNodeGroup) DeepCopy() *RuleWithNodeGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpilloverPolicy) DeepCopyInto(out *SpilloverPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpilloverPolicy.
+func (in *SpilloverPolicy) DeepCopy() *SpilloverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SpilloverPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticNodeGroupWeight) DeepCopyInto(out *StaticNodeGroupWeight) {
 	*out = *in
@@ -404,3 +696,23 @@ func (in *StaticNodeGroupWeight) DeepCopy() *StaticNodeGroupWeight {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacementStatus) DeepCopyInto(out *WorkloadPlacementStatus) {
+	*out = *in
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]NodeGroupPodsStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacementStatus.
+func (in *WorkloadPlacementStatus) DeepCopy() *WorkloadPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
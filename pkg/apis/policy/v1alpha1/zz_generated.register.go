@@ -57,6 +57,12 @@ func init() {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ClusterOverridePolicy{},
+		&ClusterOverridePolicyList{},
+		&ClusterPropagationPolicy{},
+		&ClusterPropagationPolicyList{},
+		&OverridePolicy{},
+		&OverridePolicyList{},
 		&PropagationPolicy{},
 		&PropagationPolicyList{},
 	)
@@ -0,0 +1,7 @@
+package v1beta1
+
+// Hub marks PropagationPolicy of v1beta1 as the version other versions are converted to and from.
+func (*PropagationPolicy) Hub() {}
+
+// Hub marks ClusterPropagationPolicy of v1beta1 as the version other versions are converted to and from.
+func (*ClusterPropagationPolicy) Hub() {}
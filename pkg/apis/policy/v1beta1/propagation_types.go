@@ -0,0 +1,381 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PropagationPolicySpec represents the desired behavior of PropagationPolicy.
+type PropagationPolicySpec struct {
+	// ResourceSelectors used to select resources. Only apps/v1 Deployments are supported.
+	// +required
+	ResourceSelectors []ResourceSelector `json:"resourceSelectors"`
+
+	// Priority of the policy. A workload selected by multiple policies is propagated by the one
+	// with the highest priority. Among policies with the same priority, namespaced policies take
+	// precedence over cluster-scoped ones, then the one selecting the workload more specifically,
+	// by name, by labelSelector, or all deployments in the namespace, and then the one with the
+	// smaller namespace and name. Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Placement represents the nodegroups to propagate resources to.
+	// +optional
+	Placement Placement `json:"placement,omitempty"`
+
+	// Rebalance represents how pods are moved across nodegroups when they are not
+	// distributed as desired.
+	// +optional
+	Rebalance RebalanceStrategy `json:"rebalance,omitempty"`
+
+	// Failover represents how pods are re-routed when target nodegroups go offline.
+	// +optional
+	Failover FailoverPolicy `json:"failover,omitempty"`
+
+	// Spillover represents where pods are placed when nodegroups which need more pods
+	// have no capacity for them.
+	// +optional
+	Spillover SpilloverPolicy `json:"spillover,omitempty"`
+
+	// RestartWorkloadsOnDeletion means workloads selected by the policy will be restarted
+	// in a rolling way when the policy is deleted, so that their pods are rescheduled
+	// without the placement of the policy.
+	// +optional
+	RestartWorkloadsOnDeletion bool `json:"restartWorkloadsOnDeletion,omitempty"`
+
+	// AnnotatePodTemplate means the policy is also recorded in the pod template of workloads
+	// selected by it, so that the scheduler extender finds the policy from annotations of pods.
+	// Workloads are rolled out when the annotation is added, and when it is removed after the
+	// policy is deleted.
+	// +optional
+	AnnotatePodTemplate bool `json:"annotatePodTemplate,omitempty"`
+}
+
+// PropagationPolicyStatus defines the observed state of PropagationPolicy
+type PropagationPolicyStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// MatchedWorkloads is the number of workloads selected by the policy.
+	// +optional
+	MatchedWorkloads int32 `json:"matchedWorkloads,omitempty"`
+
+	// BalanceState represents whether pods of all selected workloads are
+	// distributed across nodegroups as desired.
+	// +optional
+	BalanceState BalanceState `json:"balanceState,omitempty"`
+
+	// Workloads contains the placement status of each selected workload.
+	// +optional
+	Workloads []WorkloadPlacementStatus `json:"workloads,omitempty"`
+
+	// FailedOverNodeGroups are target nodegroups which are offline, whose share of pods
+	// is redistributed to other target nodegroups.
+	// +optional
+	FailedOverNodeGroups []FailedOverNodeGroup `json:"failedOverNodeGroups,omitempty"`
+
+	// Conditions contain the different condition statuses of the policy.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// FailedOverNodeGroup represents a target nodegroup which has been failed over.
+type FailedOverNodeGroup struct {
+	// Name of the nodegroup.
+	// +required
+	Name string `json:"name"`
+
+	// FailedOverTime is the time when the share of pods of the nodegroup was redistributed.
+	// +required
+	FailedOverTime metav1.Time `json:"failedOverTime"`
+}
+
+// These are valid conditions of a PropagationPolicy.
+const (
+	// NodeGroupsAvailable means all target nodegroups of the policy exist,
+	// are not being deleted and contain nodes.
+	NodeGroupsAvailable = "NodeGroupsAvailable"
+
+	// FailedOver means some target nodegroups are offline and their share of pods
+	// is redistributed to other target nodegroups.
+	FailedOver = "FailedOver"
+
+	// Shadowed means some workloads selected by the policy are propagated by other
+	// policies which take precedence over it.
+	Shadowed = "Shadowed"
+)
+
+// FailoverPolicy describes how pods are re-routed when target nodegroups go offline.
+type FailoverPolicy struct {
+	// Enabled means when all nodes of a target nodegroup are not ready for TolerationSeconds,
+	// its share of pods is redistributed to other target nodegroups by their weights, until
+	// the nodegroup has been ready again for RecoverySeconds.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TolerationSeconds is how long all nodes of a nodegroup can be not ready before it is
+	// failed over. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TolerationSeconds *int32 `json:"tolerationSeconds,omitempty"`
+
+	// RecoverySeconds is how long a failed over nodegroup must be ready again before its
+	// share of pods is restored. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RecoverySeconds *int32 `json:"recoverySeconds,omitempty"`
+}
+
+// SpilloverPolicy describes where pods are placed when nodegroups lack capacity.
+type SpilloverPolicy struct {
+	// Enabled means when none of the nodegroups which need more pods has a feasible node
+	// for a pod, the pod can be placed in other target nodegroups, or in OverflowNodeGroup
+	// if specified. Spilled pods are moved back once the nodegroups which need more pods
+	// have enough allocatable resources for them. Pods spilled to OverflowNodeGroup are
+	// stray pods, which are not moved back if Rebalance.KeepStrayPods is set.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// OverflowNodeGroup is the nodegroup where pods are placed when they spill over.
+	// If empty, pods spill over to other target nodegroups.
+	// +optional
+	OverflowNodeGroup string `json:"overflowNodeGroup,omitempty"`
+}
+
+// RebalanceStrategy describes how pods are moved across nodegroups.
+type RebalanceStrategy struct {
+	// Type of the rebalance strategy, either "Evict" or "Delete". Defaults to "Evict".
+	// +kubebuilder:validation:Enum=Evict;Delete
+	// +kubebuilder:default=Evict
+	// +optional
+	Type RebalanceStrategyType `json:"type,omitempty"`
+
+	// MaxUnavailable is the maximum number of pods of a workload that can be unavailable
+	// while rebalancing, either an absolute number or a percentage of the desired replicas.
+	// Surplus pods are evicted only when the number of unavailable pods is below it, so that
+	// replacements become ready before more pods are moved. Percentages are rounded down.
+	// 0 means ready pods are never evicted, and only surplus pods which are not ready are moved.
+	// The last ready pod of a workload is never evicted, so ready pods of a workload with one
+	// replica are not moved either. Only used by the "Evict" strategy. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// KeepStrayPods means pods running on nodes outside the target nodegroups, such as
+	// after the policy is edited or nodes are relabeled, are left as they are. Otherwise,
+	// they are moved into the target nodegroups like surplus pods.
+	// +optional
+	KeepStrayPods bool `json:"keepStrayPods,omitempty"`
+}
+
+// RebalanceStrategyType is the type of a rebalance strategy.
+type RebalanceStrategyType string
+
+const (
+	// EvictRebalanceStrategy evicts surplus pods through the Eviction API, which respects
+	// PodDisruptionBudgets. Not ready and newest pods are evicted first, and no more than
+	// MaxUnavailable pods are unavailable at the same time.
+	EvictRebalanceStrategy RebalanceStrategyType = "Evict"
+
+	// DeleteRebalanceStrategy deletes all surplus pods at once.
+	DeleteRebalanceStrategy RebalanceStrategyType = "Delete"
+)
+
+// BalanceState describes whether the pods are distributed as the policy desires.
+type BalanceState string
+
+const (
+	// Balanced means all pods are distributed as desired.
+	Balanced BalanceState = "Balanced"
+
+	// Unbalanced means some nodegroups have more or less pods than desired.
+	Unbalanced BalanceState = "Unbalanced"
+
+	// BalanceUnknown means the controller failed to figure out the distribution.
+	BalanceUnknown BalanceState = "Unknown"
+)
+
+// WorkloadPlacementStatus represents the distribution of pods of a workload.
+type WorkloadPlacementStatus struct {
+	// APIVersion represents the API version of the workload.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind represents the Kind of the workload.
+	// +required
+	Kind string `json:"kind"`
+
+	// Namespace of the workload.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the workload.
+	// +required
+	Name string `json:"name"`
+
+	// Replicas is the desired number of pods of the workload.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// NodeGroups contains the desired and current number of pods in each target nodegroup.
+	// +optional
+	NodeGroups []NodeGroupPodsStatus `json:"nodeGroups,omitempty"`
+
+	// StrayPods is the number of pods running on nodes outside the target nodegroups.
+	// +optional
+	StrayPods int32 `json:"strayPods,omitempty"`
+}
+
+// NodeGroupPodsStatus represents the number of pods of a workload in a nodegroup.
+type NodeGroupPodsStatus struct {
+	// Name of the nodegroup.
+	// +required
+	Name string `json:"name"`
+
+	// Desired is the number of pods that should run in the nodegroup.
+	// +optional
+	Desired int32 `json:"desired,omitempty"`
+
+	// Current is the number of pods running in the nodegroup.
+	// +optional
+	Current int32 `json:"current,omitempty"`
+}
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=pp
+//+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+//+kubebuilder:printcolumn:name="Workloads",type="integer",JSONPath=".status.matchedWorkloads"
+//+kubebuilder:printcolumn:name="Balance",type="string",JSONPath=".status.balanceState"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PropagationPolicy represents the policy that propagates a group of resources to one or more nodegroups.
+// A resource selected by both a PropagationPolicy and a ClusterPropagationPolicy with the same priority
+// is propagated by the PropagationPolicy.
+type PropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec represents the desired behavior of PropagationPolicy.
+	// +required
+	Spec PropagationPolicySpec `json:"spec"`
+
+	// Status represents the observed state of PropagationPolicy.
+	// +optional
+	Status PropagationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PropagationPolicyList contains a list of PropagationPolicy
+type PropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PropagationPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster,shortName=cpp
+//+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+//+kubebuilder:printcolumn:name="Workloads",type="integer",JSONPath=".status.matchedWorkloads"
+//+kubebuilder:printcolumn:name="Balance",type="string",JSONPath=".status.balanceState"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterPropagationPolicy represents the cluster-wide policy that propagates a group of resources
+// to one or more nodegroups. Its resource selectors must specify the namespace.
+type ClusterPropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec represents the desired behavior of ClusterPropagationPolicy.
+	// +required
+	Spec PropagationPolicySpec `json:"spec"`
+
+	// Status represents the observed state of ClusterPropagationPolicy.
+	// +optional
+	Status PropagationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterPropagationPolicyList contains a list of ClusterPropagationPolicy
+type ClusterPropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPropagationPolicy `json:"items"`
+}
+
+// ResourceSelector the resources will be selected.
+type ResourceSelector struct {
+	// APIVersion represents the API version of the target resources.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind represents the Kind of the target resources.
+	// +required
+	Kind string `json:"kind"`
+
+	// Namespace of the target resource.
+	// Defaults to the namespace of the policy. Required by cluster-scoped policies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the target resource.
+	// Default is empty, which means selecting all resources.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// A label query over a set of resources.
+	// If name is not empty, labelSelector will be ignored.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// Placement describes the nodegroups pods are propagated to.
+type Placement struct {
+	// NodeGroups are the target nodegroups. Pods of a workload are distributed across
+	// them in proportion to their weights.
+	// +optional
+	NodeGroups []NodeGroupWeight `json:"nodeGroups,omitempty"`
+
+	// SplitByChildGroups means pods desired in a nodegroup with child nodegroups are
+	// further split across its child nodegroups according to their weights, level by
+	// level, such as first across regions and then across sites within each region.
+	// +optional
+	SplitByChildGroups bool `json:"splitByChildGroups,omitempty"`
+}
+
+// NodeGroupWeight is a target nodegroup and its weight.
+type NodeGroupWeight struct {
+	// Name of the nodegroup.
+	// +required
+	Name string `json:"name"`
+
+	// Weight expressing the preference to the nodegroup. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+}
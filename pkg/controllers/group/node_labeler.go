@@ -0,0 +1,166 @@
+package nodegroup
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+)
+
+// syncNodeLabelsAndTaints adds the nodegroup label and taints to the members of the nodegroup,
+// and removes them from nodes which used to be members. Taints are removed only if they have
+// been added by the nodegroup as recorded in NodeGroupTaintsAnnotation of the node.
+func (c *Controller) syncNodeLabelsAndTaints(ctx context.Context, nodeGroup *groupv1alpha1.NodeGroup, nodes []corev1.Node, members sets.String) error {
+	formerMembers := sets.NewString(nodeGroup.Status.ContainedNodes...)
+
+	errs := []error{}
+	for i := range nodes {
+		node := &nodes[i]
+		isMember := members.Has(node.Name)
+		labeled := node.Labels[groupv1alpha1.NodeGroupLabel] == nodeGroup.Name
+		appliedTaints := getAppliedTaints(node)
+		_, recorded := appliedTaints[nodeGroup.Name]
+		if !isMember && !labeled && !recorded && !formerMembers.Has(node.Name) {
+			continue
+		}
+
+		updated := node.DeepCopy()
+		labelChanged := false
+		switch {
+		case isMember && nodeGroup.Spec.LabelNodes && !labeled:
+			if owner, ok := node.Labels[groupv1alpha1.NodeGroupLabel]; ok {
+				klog.V(2).Infof("node %s has been labeled by nodegroup %s, skip labeling it for nodegroup %s", node.Name, owner, nodeGroup.Name)
+				break
+			}
+			if updated.Labels == nil {
+				updated.Labels = make(map[string]string)
+			}
+			updated.Labels[groupv1alpha1.NodeGroupLabel] = nodeGroup.Name
+			labelChanged = true
+		case (!isMember || !nodeGroup.Spec.LabelNodes) && labeled:
+			delete(updated.Labels, groupv1alpha1.NodeGroupLabel)
+			labelChanged = true
+		}
+
+		var desiredTaints []corev1.Taint
+		if isMember {
+			desiredTaints = nodeGroup.Spec.NodeTaints
+		}
+		taints, applied, taintsChanged := updateTaints(node.Spec.Taints, desiredTaints, appliedTaints[nodeGroup.Name])
+		updated.Spec.Taints = taints
+		if !equality.Semantic.DeepEqual(applied, appliedTaints[nodeGroup.Name]) {
+			if len(applied) == 0 {
+				delete(appliedTaints, nodeGroup.Name)
+			} else {
+				appliedTaints[nodeGroup.Name] = applied
+			}
+			if err := setAppliedTaints(updated, appliedTaints); err != nil {
+				klog.Errorf("Failed to record taints of node %s for nodegroup %s, %v", node.Name, nodeGroup.Name, err)
+				errs = append(errs, err)
+				continue
+			}
+			taintsChanged = true
+		}
+
+		if !labelChanged && !taintsChanged {
+			continue
+		}
+		klog.V(2).Infof("updating labels and taints of node %s for nodegroup %s", node.Name, nodeGroup.Name)
+		if err := c.Client.Patch(ctx, updated, client.MergeFromWithOptions(node, client.MergeFromWithOptimisticLock{})); err != nil {
+			klog.Errorf("Failed to update labels and taints of node %s for nodegroup %s, %v", node.Name, nodeGroup.Name, err)
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateTaints returns taints with desired taints added and taints applied before removed, the desired
+// taints which have been applied, and whether the taints have been changed. Taints are identified by key
+// and effect. Only taints with the same value as the applied ones are removed or replaced, so taints
+// which were not added by the nodegroup are kept, even if they conflict with or equal desired taints.
+func updateTaints(taints []corev1.Taint, desired []corev1.Taint, applied []corev1.Taint) ([]corev1.Taint, []corev1.Taint, bool) {
+	updated := []corev1.Taint{}
+	changed := false
+	for i := range taints {
+		taint := &taints[i]
+		desiredTaint := findTaint(desired, taint)
+		if isAppliedTaint(applied, taint) && (desiredTaint == nil || desiredTaint.Value != taint.Value) {
+			// removed, or replaced with the desired one
+			changed = true
+			continue
+		}
+		if desiredTaint != nil && desiredTaint.Value != taint.Value {
+			klog.V(2).Infof("taint %s existed before being applied, skip overwriting it", taint.ToString())
+		}
+		updated = append(updated, *taint)
+	}
+
+	nowApplied := []corev1.Taint{}
+	for i := range desired {
+		existing := findTaint(updated, &desired[i])
+		switch {
+		case existing == nil:
+			updated = append(updated, desired[i])
+			nowApplied = append(nowApplied, desired[i])
+			changed = true
+		case isAppliedTaint(applied, existing):
+			nowApplied = append(nowApplied, *existing)
+		}
+	}
+	return updated, nowApplied, changed
+}
+
+// isAppliedTaint returns true if the taint has been applied with the same value.
+func isAppliedTaint(applied []corev1.Taint, taint *corev1.Taint) bool {
+	appliedTaint := findTaint(applied, taint)
+	return appliedTaint != nil && appliedTaint.Value == taint.Value
+}
+
+func findTaint(taints []corev1.Taint, taint *corev1.Taint) *corev1.Taint {
+	for i := range taints {
+		if taints[i].MatchTaint(taint) {
+			return &taints[i]
+		}
+	}
+	return nil
+}
+
+// getAppliedTaints returns the taints added to the node by each nodegroup, as recorded in
+// NodeGroupTaintsAnnotation of the node.
+func getAppliedTaints(node *corev1.Node) map[string][]corev1.Taint {
+	applied := map[string][]corev1.Taint{}
+	value, ok := node.Annotations[groupv1alpha1.NodeGroupTaintsAnnotation]
+	if !ok {
+		return applied
+	}
+	if err := json.Unmarshal([]byte(value), &applied); err != nil {
+		klog.Errorf("Failed to parse taints applied to node %s, %v", node.Name, err)
+		return map[string][]corev1.Taint{}
+	}
+	return applied
+}
+
+// setAppliedTaints records the taints added to the node by each nodegroup in NodeGroupTaintsAnnotation
+// of the node, and removes the annotation if no taint is added.
+func setAppliedTaints(node *corev1.Node, applied map[string][]corev1.Taint) error {
+	if len(applied) == 0 {
+		delete(node.Annotations, groupv1alpha1.NodeGroupTaintsAnnotation)
+		return nil
+	}
+	value, err := json.Marshal(applied)
+	if err != nil {
+		return err
+	}
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[groupv1alpha1.NodeGroupTaintsAnnotation] = string(value)
+	return nil
+}
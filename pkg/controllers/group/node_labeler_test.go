@@ -0,0 +1,97 @@
+package nodegroup
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUpdateTaints(t *testing.T) {
+	newTaint := func(key, value string) corev1.Taint {
+		return corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffectNoSchedule}
+	}
+
+	cases := []struct {
+		name        string
+		taints      []corev1.Taint
+		desired     []corev1.Taint
+		applied     []corev1.Taint
+		want        []corev1.Taint
+		wantApplied []corev1.Taint
+		wantChanged bool
+	}{
+		{
+			name:        "add desired taints",
+			taints:      []corev1.Taint{newTaint("other", "")},
+			desired:     []corev1.Taint{newTaint("edge", "true")},
+			want:        []corev1.Taint{newTaint("other", ""), newTaint("edge", "true")},
+			wantApplied: []corev1.Taint{newTaint("edge", "true")},
+			wantChanged: true,
+		},
+		{
+			name:        "keep desired taints",
+			taints:      []corev1.Taint{newTaint("edge", "true")},
+			desired:     []corev1.Taint{newTaint("edge", "true")},
+			applied:     []corev1.Taint{newTaint("edge", "true")},
+			want:        []corev1.Taint{newTaint("edge", "true")},
+			wantApplied: []corev1.Taint{newTaint("edge", "true")},
+		},
+		{
+			name:        "replace applied taints",
+			taints:      []corev1.Taint{newTaint("edge", "true")},
+			desired:     []corev1.Taint{newTaint("edge", "false")},
+			applied:     []corev1.Taint{newTaint("edge", "true")},
+			want:        []corev1.Taint{newTaint("edge", "false")},
+			wantApplied: []corev1.Taint{newTaint("edge", "false")},
+			wantChanged: true,
+		},
+		{
+			name:    "never overwrite taints existing before",
+			taints:  []corev1.Taint{newTaint("edge", "user")},
+			desired: []corev1.Taint{newTaint("edge", "true")},
+			want:    []corev1.Taint{newTaint("edge", "user")},
+		},
+		{
+			name:    "never record identical taints existing before",
+			taints:  []corev1.Taint{newTaint("edge", "true")},
+			desired: []corev1.Taint{newTaint("edge", "true")},
+			want:    []corev1.Taint{newTaint("edge", "true")},
+		},
+		{
+			name:   "keep identical taints existing before",
+			taints: []corev1.Taint{newTaint("edge", "true")},
+			want:   []corev1.Taint{newTaint("edge", "true")},
+		},
+		{
+			name:        "remove applied taints",
+			taints:      []corev1.Taint{newTaint("edge", "true"), newTaint("other", "")},
+			applied:     []corev1.Taint{newTaint("edge", "true")},
+			want:        []corev1.Taint{newTaint("other", "")},
+			wantChanged: true,
+		},
+		{
+			name:    "keep taints applied with other values",
+			taints:  []corev1.Taint{newTaint("edge", "user")},
+			applied: []corev1.Taint{newTaint("edge", "true")},
+			want:    []corev1.Taint{newTaint("edge", "user")},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, applied, changed := updateTaints(c.taints, c.desired, c.applied)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want taints %v, got %v", c.want, got)
+			}
+			if len(applied) != 0 || len(c.wantApplied) != 0 {
+				if !reflect.DeepEqual(applied, c.wantApplied) {
+					t.Errorf("want applied taints %v, got %v", c.wantApplied, applied)
+				}
+			}
+			if changed != c.wantChanged {
+				t.Errorf("want changed %v, got %v", c.wantChanged, changed)
+			}
+		})
+	}
+}
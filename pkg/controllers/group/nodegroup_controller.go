@@ -2,19 +2,35 @@ package nodegroup
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
 )
 
 const (
@@ -23,14 +39,34 @@ const (
 	// MonitorRetrySleepTime is the amount of time the node group controller that should
 	// sleep between retrying NodeGroup updates.
 	MonitorRetrySleepTime = 20 * time.Millisecond
+	// NodeGroupFinalizer is the finalizer added to NodeGroups to clean up nodes before they are deleted.
+	NodeGroupFinalizer = "group.kubeedge.io/nodegroup-controller"
+	// DeletionBlockedRetryPeriod is the amount of time to wait before checking again whether
+	// a nodegroup being deleted is still referenced by policies.
+	DeletionBlockedRetryPeriod = 30 * time.Second
 )
 
+// Reasons of nodegroup conditions.
+const (
+	reasonNodesAvailable       = "NodesAvailable"
+	reasonAllNodesAvailable    = "AllNodesAvailable"
+	reasonNodesUnavailable     = "NodesUnavailable"
+	reasonNoAvailableNodes     = "NoAvailableNodes"
+	reasonNoNodes              = "NoNodes"
+	reasonNodesReady           = "NodesReady"
+	reasonNoReadyNodes         = "NoReadyNodes"
+	reasonNoOverlap            = "NoOverlap"
+	reasonNodesOverlapped      = "NodesOverlapped"
+	reasonExclusiveConflict    = "ExclusiveConflict"
+	reasonReferencedByPolicies = "ReferencedByPolicies"
+)
+
+// aggregatedResources are the resources aggregated in the status of nodegroups.
+var aggregatedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourcePods}
+
 // Controller is to sync NodeGroup.
 type Controller struct {
 	client.Client
-
-	// TODO:
-	// not used
 	EventRecorder record.EventRecorder
 }
 
@@ -51,57 +87,496 @@ func (c *Controller) Reconcile(ctx context.Context, req controllerruntime.Reques
 	}
 
 	if !nodeGroup.DeletionTimestamp.IsZero() {
-		return c.removeNodeGroup(nodeGroup)
+		return c.removeNodeGroup(ctx, nodeGroup)
+	}
+
+	if !controllerutil.ContainsFinalizer(nodeGroup, NodeGroupFinalizer) {
+		controllerutil.AddFinalizer(nodeGroup, NodeGroupFinalizer)
+		if err := c.Client.Update(ctx, nodeGroup); err != nil {
+			klog.Errorf("Error while adding finalizer to nodegroup %s, err: %v", nodeGroup.Name, err)
+			return controllerruntime.Result{Requeue: true}, err
+		}
 	}
 
-	return c.syncNodeGroup(nodeGroup)
+	return c.syncNodeGroup(ctx, nodeGroup)
 }
 
-func (c *Controller) syncNodeGroup(nodeGroup *groupv1alpha1.NodeGroup) (controllerruntime.Result, error) {
-	matchLables := nodeGroup.Spec.MatchLabels
+func (c *Controller) syncNodeGroup(ctx context.Context, nodeGroup *groupv1alpha1.NodeGroup) (controllerruntime.Result, error) {
+	nodeList := &corev1.NodeList{}
+	if err := c.Client.List(ctx, nodeList); err != nil {
+		klog.Errorf("Error while listing nodes for nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+
+	_, missingNodes, err := utils.MatchNodesInGroup(nodeList.Items, nodeGroup)
+	if err != nil {
+		klog.Errorf("Error while matching nodes for nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{}, err
+	}
+	if len(missingNodes) != 0 {
+		klog.Warningf("nodes %v of nodegroup %s do not exist", missingNodes, nodeGroup.Name)
+	}
 
-	nodeList, err := c.GetNodesByLabelSelector(labels.SelectorFromSet(labels.Set(matchLables)))
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(ctx, groupList); err != nil {
+		klog.Errorf("Error while listing nodegroups, err: %v", err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+	membership, err := utils.GetNodeGroupMembership(nodeList.Items, groupList.Items)
+	if err != nil {
+		klog.Errorf("Error while getting membership of nodes, err: %v", err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+	overlapping, err := c.getReportedOverlappingNodes(nodeList.Items, nodeGroup, groupList.Items)
 	if err != nil {
-		klog.Errorf("Error while get nodes by labels %v. err: ", matchLables, err)
+		klog.Errorf("Error while getting overlapping nodes of nodegroup %s, err: %v", nodeGroup.Name, err)
 		return controllerruntime.Result{Requeue: true}, err
 	}
 
 	var containedNodes []string
+	var readyNodes, unschedulableNodes, availableNodes int32
+	allocatable := corev1.ResourceList{}
 	for k := range nodeList.Items {
-		containedNodes = append(containedNodes, nodeList.Items[k].Name)
+		node := &nodeList.Items[k]
+		// membership includes nodes of child nodegroups and excludes nodes
+		// owned by other exclusive nodegroups
+		if !sets.NewString(membership[node.Name]...).Has(nodeGroup.Name) {
+			continue
+		}
+		containedNodes = append(containedNodes, node.Name)
+		ready := isNodeReady(node)
+		if ready {
+			readyNodes++
+		}
+		if node.Spec.Unschedulable {
+			unschedulableNodes++
+		}
+		if ready && !node.Spec.Unschedulable {
+			availableNodes++
+		}
+		for _, name := range aggregatedResources {
+			if quantity, ok := node.Status.Allocatable[name]; ok {
+				utils.AddResourceList(allocatable, corev1.ResourceList{name: quantity})
+			}
+		}
+	}
+	// keep the order stable to avoid meaningless status updates
+	sort.Strings(containedNodes)
+
+	if err := c.syncNodeLabelsAndTaints(ctx, nodeGroup, nodeList.Items, sets.NewString(containedNodes...)); err != nil {
+		return controllerruntime.Result{Requeue: true}, err
 	}
 
-	if !equality.Semantic.DeepEqual(nodeGroup.Status.ContainedNodes, containedNodes) {
-		nodeGroup.Status.ContainedNodes = containedNodes
-		c.Status().Update(context.TODO(), nodeGroup)
+	requested, err := c.getRequestedResources(ctx, sets.NewString(containedNodes...))
+	if err != nil {
+		klog.Errorf("Error while calculating resources requested in nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{Requeue: true}, err
 	}
 
+	totalNodes := int32(len(containedNodes))
+	status := groupv1alpha1.NodeGroupStatus{
+		ContainedNodes:     containedNodes,
+		MissingNodes:       missingNodes,
+		TotalNodes:         totalNodes,
+		ReadyNodes:         readyNodes,
+		NotReadyNodes:      totalNodes - readyNodes,
+		UnschedulableNodes: unschedulableNodes,
+		Allocatable:        allocatable,
+		Requested:          requested,
+		AppliedTaints:      nodeGroup.Spec.NodeTaints,
+		Conditions:         newNodeGroupConditions(nodeGroup, totalNodes, readyNodes, availableNodes, overlapping),
+	}
+	if equality.Semantic.DeepEqual(nodeGroup.Status, status) {
+		return controllerruntime.Result{}, nil
+	}
+
+	becomeEmpty := nodeGroup.Status.TotalNodes != 0 && status.TotalNodes == 0
+	nodeGroup.Status = status
+	if err := c.Status().Update(ctx, nodeGroup); err != nil {
+		if apierrors.IsConflict(err) {
+			klog.V(2).Infof("Conflict while updating status of nodegroup %s, retry later", nodeGroup.Name)
+			return controllerruntime.Result{RequeueAfter: MonitorRetrySleepTime}, nil
+		}
+		klog.Errorf("Error while updating status of nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+
+	if becomeEmpty {
+		c.EventRecorder.Eventf(nodeGroup, corev1.EventTypeWarning, events.EventReasonNodeGroupEmpty,
+			"NodeGroup %s no longer contains any node", nodeGroup.Name)
+	}
 	return controllerruntime.Result{}, nil
 }
 
+// getRequestedResources sums up resources requested by pods running on the nodes.
+func (c *Controller) getRequestedResources(ctx context.Context, nodes sets.String) (corev1.ResourceList, error) {
+	requested := corev1.ResourceList{}
+	if nodes.Len() == 0 {
+		return requested, nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := c.Client.List(ctx, podList); err != nil {
+		return nil, err
+	}
+
+	var podsNum int64
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !nodes.Has(pod.Spec.NodeName) || isPodTerminated(pod) {
+			continue
+		}
+		utils.AddResourceList(requested, utils.GetPodRequests(pod))
+		podsNum++
+	}
+	requested[corev1.ResourcePods] = *resource.NewQuantity(podsNum, resource.DecimalSI)
+	return requested, nil
+}
+
+// getReportedOverlappingNodes returns overlapping nodes that should be reported in the status
+// of the nodegroup. An exclusive nodegroup only reports nodes shared with other exclusive nodegroups,
+// because it always owns nodes shared with non-exclusive nodegroups.
+func (c *Controller) getReportedOverlappingNodes(nodes []corev1.Node, nodeGroup *groupv1alpha1.NodeGroup, groups []groupv1alpha1.NodeGroup) (map[string][]string, error) {
+	if !nodeGroup.Spec.Exclusive {
+		return utils.GetOverlappingNodes(nodes, nodeGroup, groups)
+	}
+	// the same conflicts are rejected by ValidateExclusiveNodeGroup when the nodegroup
+	// is admitted, but nodes can still come to be shared when they are relabeled
+	return utils.GetExclusiveOverlappingNodes(nodes, nodeGroup, groups)
+}
+
+// newNodeGroupConditions returns the conditions of the nodegroup according to the number
+// of its nodes, the number of nodes which are ready, the number of nodes which are ready and
+// schedulable and nodes shared with other nodegroups.
+func newNodeGroupConditions(nodeGroup *groupv1alpha1.NodeGroup, totalNodes, readyNodes, availableNodes int32, overlapping map[string][]string) []metav1.Condition {
+	conditions := nodeGroup.Status.DeepCopy().Conditions
+
+	readyCondition := metav1.Condition{
+		Type:               groupv1alpha1.NodeGroupReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: nodeGroup.Generation,
+		Reason:             reasonNodesAvailable,
+		Message:            fmt.Sprintf("%d of %d nodes are ready and schedulable", availableNodes, totalNodes),
+	}
+	degradedCondition := metav1.Condition{
+		Type:               groupv1alpha1.NodeGroupDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nodeGroup.Generation,
+		Reason:             reasonAllNodesAvailable,
+		Message:            readyCondition.Message,
+	}
+
+	switch {
+	case totalNodes == 0:
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = reasonNoNodes
+		readyCondition.Message = "NodeGroup contains no node"
+		degradedCondition.Reason = reasonNoNodes
+		degradedCondition.Message = readyCondition.Message
+	case availableNodes == 0:
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = reasonNoAvailableNodes
+		fallthrough
+	case availableNodes < totalNodes:
+		degradedCondition.Status = metav1.ConditionTrue
+		degradedCondition.Reason = reasonNodesUnavailable
+		degradedCondition.Message = fmt.Sprintf("%d of %d nodes are not ready or unschedulable", totalNodes-availableNodes, totalNodes)
+	}
+
+	offlineCondition := metav1.Condition{
+		Type:               groupv1alpha1.NodeGroupOffline,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nodeGroup.Generation,
+		Reason:             reasonNodesReady,
+		Message:            fmt.Sprintf("%d of %d nodes are ready", readyNodes, totalNodes),
+	}
+	switch {
+	case totalNodes == 0:
+		offlineCondition.Reason = reasonNoNodes
+		offlineCondition.Message = "NodeGroup contains no node"
+	case readyNodes == 0:
+		offlineCondition.Status = metav1.ConditionTrue
+		offlineCondition.Reason = reasonNoReadyNodes
+		offlineCondition.Message = fmt.Sprintf("None of %d nodes is ready", totalNodes)
+	}
+
+	overlappedCondition := metav1.Condition{
+		Type:               groupv1alpha1.NodeGroupOverlapped,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nodeGroup.Generation,
+		Reason:             reasonNoOverlap,
+		Message:            "No node is shared with other nodegroups",
+	}
+	if len(overlapping) != 0 {
+		overlappedCondition.Status = metav1.ConditionTrue
+		overlappedCondition.Reason = reasonNodesOverlapped
+		if nodeGroup.Spec.Exclusive {
+			overlappedCondition.Reason = reasonExclusiveConflict
+		}
+		overlappedCondition.Message = fmt.Sprintf("Nodes are also matched by other nodegroups: %s",
+			utils.FormatOverlappingNodes(overlapping))
+	}
+
+	meta.SetStatusCondition(&conditions, readyCondition)
+	meta.SetStatusCondition(&conditions, degradedCondition)
+	meta.SetStatusCondition(&conditions, offlineCondition)
+	meta.SetStatusCondition(&conditions, overlappedCondition)
+	return conditions
+}
+
 // SetupWithManager creates a controller and register to controller manager.
 func (c *Controller) SetupWithManager(mgr controllerruntime.Manager) error {
 	return utilerrors.NewAggregate([]error{
-		controllerruntime.NewControllerManagedBy(mgr).For(&groupv1alpha1.NodeGroup{}).Complete(c),
+		controllerruntime.NewControllerManagedBy(mgr).
+			For(&groupv1alpha1.NodeGroup{}).
+			// membership of other nodegroups may change when a nodegroup changes,
+			// because of the exclusive nodegroup and the overlapping detection.
+			Watches(&source.Kind{Type: &groupv1alpha1.NodeGroup{}},
+				handler.EnqueueRequestsFromMapFunc(c.newNodeGroupMapFunc),
+				builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			// watch changes of nodes and enqueue nodegroups which contain
+			// or used to contain the node.
+			Watches(&source.Kind{Type: &corev1.Node{}},
+				handler.EnqueueRequestsFromMapFunc(c.newNodeMapFunc),
+				builder.WithPredicates(nodePredicate)).
+			// watch changes of pods to keep requested resources of nodegroups current.
+			Watches(&source.Kind{Type: &corev1.Pod{}},
+				handler.EnqueueRequestsFromMapFunc(c.newPodMapFunc),
+				builder.WithPredicates(podPredicate)).
+			// watch policies to continue the deletion of nodegroups once they are
+			// no longer referenced.
+			Watches(&source.Kind{Type: &policyv1alpha1.PropagationPolicy{}},
+				handler.EnqueueRequestsFromMapFunc(c.newPolicyMapFunc),
+				builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&source.Kind{Type: &policyv1alpha1.ClusterPropagationPolicy{}},
+				handler.EnqueueRequestsFromMapFunc(c.newPolicyMapFunc),
+				builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Complete(c),
 	})
 }
 
-func (c *Controller) removeNodeGroup(nodeGroup *groupv1alpha1.NodeGroup) (controllerruntime.Result, error) {
-	if err := c.Client.Delete(context.TODO(), nodeGroup); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("Error while deleting nodegroup %s: %s", nodeGroup)
+func (c *Controller) newNodeMapFunc(obj client.Object) []controllerruntime.Request {
+	node := obj.(*corev1.Node)
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(context.TODO(), groupList); err != nil {
+		klog.Errorf("Failed to list nodegroups, %v", err)
+		return nil
+	}
+
+	// the node also belongs to ancestors of nodegroups matching it
+	membership, err := utils.GetNodeGroupMembership([]corev1.Node{*node}, groupList.Items)
+	if err != nil {
+		klog.Errorf("Failed to get nodegroups node %s belongs to, %v", node.Name, err)
+		return nil
+	}
+	belongings := sets.NewString(membership[node.Name]...)
+
+	results := []controllerruntime.Request{}
+	for i := range groupList.Items {
+		group := &groupList.Items[i]
+		contained := false
+		for _, name := range group.Status.ContainedNodes {
+			if name == node.Name {
+				contained = true
+				break
+			}
+		}
+		if contained || belongings.Has(group.Name) {
+			results = append(results, controllerruntime.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: group.Namespace,
+					Name:      group.Name,
+				}})
+		}
+	}
+	return results
+}
+
+func (c *Controller) newNodeGroupMapFunc(obj client.Object) []controllerruntime.Request {
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(context.TODO(), groupList); err != nil {
+		klog.Errorf("Failed to list nodegroups, %v", err)
+		return nil
+	}
+
+	results := []controllerruntime.Request{}
+	for i := range groupList.Items {
+		if groupList.Items[i].Name == obj.GetName() {
+			continue
+		}
+		results = append(results, controllerruntime.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: groupList.Items[i].Namespace,
+				Name:      groupList.Items[i].Name,
+			}})
+	}
+	return results
+}
+
+func (c *Controller) newPodMapFunc(obj client.Object) []controllerruntime.Request {
+	pod := obj.(*corev1.Pod)
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(context.TODO(), groupList); err != nil {
+		klog.Errorf("Failed to list nodegroups, %v", err)
+		return nil
+	}
+
+	results := []controllerruntime.Request{}
+	for i := range groupList.Items {
+		group := &groupList.Items[i]
+		for _, name := range group.Status.ContainedNodes {
+			if name == pod.Spec.NodeName {
+				results = append(results, controllerruntime.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: group.Namespace,
+						Name:      group.Name,
+					}})
+				break
+			}
+		}
+	}
+	return results
+}
+
+// nodePredicate filters out node updates which cannot change the membership
+// or the status of nodegroups, such as heartbeats.
+var nodePredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		return !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+			!equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+			oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+			isNodeReady(oldNode) != isNodeReady(newNode) ||
+			!equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable)
+	},
+}
+
+// podPredicate filters out pod events which cannot change the resources requested
+// in nodegroups.
+var podPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		pod, ok := e.Object.(*corev1.Pod)
+		return ok && pod.Spec.NodeName != ""
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, ok := e.ObjectOld.(*corev1.Pod)
+		if !ok {
+			return false
+		}
+		newPod, ok := e.ObjectNew.(*corev1.Pod)
+		if !ok {
+			return false
+		}
+		return oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+			isPodTerminated(oldPod) != isPodTerminated(newPod)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		pod, ok := e.Object.(*corev1.Pod)
+		return ok && pod.Spec.NodeName != ""
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+func (c *Controller) removeNodeGroup(ctx context.Context, nodeGroup *groupv1alpha1.NodeGroup) (controllerruntime.Result, error) {
+	if !controllerutil.ContainsFinalizer(nodeGroup, NodeGroupFinalizer) {
+		return controllerruntime.Result{}, nil
+	}
+
+	// the nodegroup cannot be deleted while policies still place workloads on it
+	references, err := c.getReferencingPolicies(ctx, nodeGroup.Name)
+	if err != nil {
+		klog.Errorf("Error while checking policies referencing nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+	if len(references) != 0 {
+		return c.blockNodeGroupDeletion(ctx, nodeGroup, references)
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := c.Client.List(ctx, nodeList); err != nil {
+		klog.Errorf("Error while listing nodes for nodegroup %s, err: %v", nodeGroup.Name, err)
+		return controllerruntime.Result{Requeue: true}, err
+	}
+	// no node belongs to the nodegroup any more
+	if err := c.syncNodeLabelsAndTaints(ctx, nodeGroup, nodeList.Items, sets.NewString()); err != nil {
+		return controllerruntime.Result{Requeue: true}, err
+	}
+
+	// clear the status before the finalizer is removed, so that nothing
+	// observes a stale status of the nodegroup being deleted
+	if !equality.Semantic.DeepEqual(nodeGroup.Status, groupv1alpha1.NodeGroupStatus{}) {
+		nodeGroup.Status = groupv1alpha1.NodeGroupStatus{}
+		if err := c.Client.Status().Update(ctx, nodeGroup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return controllerruntime.Result{}, nil
+			}
+			klog.Errorf("Error while clearing status of nodegroup %s, err: %v", nodeGroup.Name, err)
+			return controllerruntime.Result{Requeue: true}, err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(nodeGroup, NodeGroupFinalizer)
+	if err := c.Client.Update(ctx, nodeGroup); err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Error while removing finalizer of nodegroup %s, err: %v", nodeGroup.Name, err)
 		return controllerruntime.Result{Requeue: true}, err
 	}
 
 	return controllerruntime.Result{}, nil
 }
 
-// GetNodesByLabelSelector get NodeList by matching label selector
-func (c *Controller) GetNodesByLabelSelector(selector labels.Selector) (*corev1.NodeList, error) {
-	nodeList := &corev1.NodeList{}
-	err := c.Client.List(context.TODO(), nodeList, &client.ListOptions{LabelSelector: selector})
-	if err != nil {
-		return nil, err
+// blockNodeGroupDeletion reports that the deletion of the nodegroup is blocked by the policies
+// referencing it, and checks again later.
+func (c *Controller) blockNodeGroupDeletion(ctx context.Context, nodeGroup *groupv1alpha1.NodeGroup, references []string) (controllerruntime.Result, error) {
+	message := fmt.Sprintf("NodeGroup is still referenced by %s", strings.Join(references, ", "))
+	klog.Infof("Deletion of nodegroup %s is blocked, %s", nodeGroup.Name, message)
+
+	conditions := nodeGroup.Status.DeepCopy().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               groupv1alpha1.NodeGroupDeletionBlocked,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: nodeGroup.Generation,
+		Reason:             reasonReferencedByPolicies,
+		Message:            message,
+	})
+	if !equality.Semantic.DeepEqual(conditions, nodeGroup.Status.Conditions) {
+		nodeGroup.Status.Conditions = conditions
+		if err := c.Client.Status().Update(ctx, nodeGroup); err != nil {
+			if apierrors.IsConflict(err) {
+				return controllerruntime.Result{RequeueAfter: MonitorRetrySleepTime}, nil
+			}
+			klog.Errorf("Error while updating status of nodegroup %s, err: %v", nodeGroup.Name, err)
+			return controllerruntime.Result{Requeue: true}, err
+		}
+		c.EventRecorder.Event(nodeGroup, corev1.EventTypeWarning, events.EventReasonNodeGroupDeletionBlocked, message)
 	}
 
-	return nodeList, nil
+	return controllerruntime.Result{RequeueAfter: DeletionBlockedRetryPeriod}, nil
+}
+
+// isPodTerminated returns true if the pod will never run again.
+func isPodTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// isNodeReady returns true if the Ready condition of the node is true.
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
@@ -0,0 +1,106 @@
+package nodegroup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+// getReferencingPolicies returns the policies which reference the nodegroup, in the
+// form of "<Kind> <namespace>/<name>" or "<ClusterKind> <name>".
+func (c *Controller) getReferencingPolicies(ctx context.Context, groupName string) ([]string, error) {
+	references := []string{}
+
+	policyList := &policyv1alpha1.PropagationPolicyList{}
+	if err := c.Client.List(ctx, policyList); err != nil {
+		return nil, fmt.Errorf("failed to list propagationpolicies, %v", err)
+	}
+	clusterPolicyList := &policyv1alpha1.ClusterPropagationPolicyList{}
+	if err := c.Client.List(ctx, clusterPolicyList); err != nil {
+		return nil, fmt.Errorf("failed to list clusterpropagationpolicies, %v", err)
+	}
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(ctx, groupList); err != nil {
+		return nil, fmt.Errorf("failed to list nodegroups, %v", err)
+	}
+	policies := policyList.Items
+	for i := range clusterPolicyList.Items {
+		policies = append(policies, *utils.ConvertClusterPropagationPolicy(&clusterPolicyList.Items[i]))
+	}
+	for i := range policies {
+		policy := &policies[i]
+		for _, name := range getReferencedNodeGroupNames(policy, groupList.Items) {
+			if name == groupName {
+				references = append(references, utils.FormatPolicy("PropagationPolicy", policy))
+				break
+			}
+		}
+	}
+
+	overridePolicies, err := utils.ListOverridePolicies(ctx, c.Client, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range overridePolicies {
+		policy := &overridePolicies[i]
+	rules:
+		for _, rule := range policy.Spec.OverrideRules {
+			for _, name := range rule.TargetNodeGroup {
+				if name == groupName {
+					references = append(references, utils.FormatPolicy("OverridePolicy", policy))
+					break rules
+				}
+			}
+		}
+	}
+
+	sort.Strings(references)
+	return references, nil
+}
+
+// newPolicyMapFunc enqueues nodegroups referenced by the policy, so that the deletion of
+// a nodegroup can continue once it is no longer referenced.
+func (c *Controller) newPolicyMapFunc(obj client.Object) []controllerruntime.Request {
+	var policy *policyv1alpha1.PropagationPolicy
+	switch o := obj.(type) {
+	case *policyv1alpha1.PropagationPolicy:
+		policy = o
+	case *policyv1alpha1.ClusterPropagationPolicy:
+		policy = utils.ConvertClusterPropagationPolicy(o)
+	default:
+		return nil
+	}
+
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := c.Client.List(context.TODO(), groupList); err != nil {
+		klog.Errorf("Failed to list nodegroups, %v", err)
+		return nil
+	}
+	results := []controllerruntime.Request{}
+	for _, name := range getReferencedNodeGroupNames(policy, groupList.Items) {
+		results = append(results, controllerruntime.Request{
+			NamespacedName: types.NamespacedName{Name: name},
+		})
+	}
+	return results
+}
+
+// getReferencedNodeGroupNames returns names of nodegroups referenced by the policy, which are the
+// nodegroups where its pods are placed, including descendants of target nodegroups if the policy
+// splits pods by child nodegroups, and the nodegroup where its pods spill over.
+func getReferencedNodeGroupNames(policy *policyv1alpha1.PropagationPolicy, groups []groupv1alpha1.NodeGroup) []string {
+	names := utils.GetPlacementNodeGroupNames(policy, groups)
+	if overflow := policy.Spec.Spillover.OverflowNodeGroup; overflow != "" {
+		names = append(names, overflow)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+package nodegroup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func TestGetReferencingPolicies(t *testing.T) {
+	groups := []client.Object{
+		&groupv1alpha1.NodeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec:       groupv1alpha1.NodeGroupSpec{ChildGroups: []groupv1alpha1.ChildNodeGroup{{Name: "hangzhou", Weight: 1}}},
+		},
+		&groupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: "hangzhou"}},
+		&groupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: "cloud"}},
+	}
+	newPolicy := func(name string, split bool, overflow string) client.Object {
+		policy := &policyv1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		policy.Spec.Placement.StaticWeightList = []policyv1alpha1.StaticNodeGroupWeight{{NodeGroupNames: []string{"east"}, Weight: 1}}
+		policy.Spec.Placement.SplitByChildGroups = split
+		policy.Spec.Spillover.OverflowNodeGroup = overflow
+		return policy
+	}
+	policies := []client.Object{
+		newPolicy("whole", false, ""),
+		newPolicy("split", true, ""),
+		newPolicy("spillover", false, "cloud"),
+	}
+
+	cases := []struct {
+		group string
+		want  []string
+	}{
+		{
+			group: "east",
+			want:  []string{"PropagationPolicy default/spillover", "PropagationPolicy default/split", "PropagationPolicy default/whole"},
+		},
+		{
+			group: "hangzhou",
+			want:  []string{"PropagationPolicy default/split"},
+		},
+		{
+			group: "cloud",
+			want:  []string{"PropagationPolicy default/spillover"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := groupv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := policyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := &Controller{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(groups, policies...)...).Build()}
+	for _, tc := range cases {
+		t.Run(tc.group, func(t *testing.T) {
+			got, err := c.getReferencingPolicies(context.TODO(), tc.group)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want references %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package override
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+const (
+	// ControllerName is the controller name that will be used when reporting events.
+	ControllerName = "overridepolicy-controller"
+)
+
+// Controller is to sync the status of OverridePolicy and ClusterOverridePolicy.
+type Controller struct {
+	client.Client
+	EventRecorder record.EventRecorder
+}
+
+// Reconcile performs a full reconciliation for the object referred to by the Request.
+// Requests without namespace are for ClusterOverridePolicies.
+func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.Infof("Reconciling override policy %s", req.NamespacedName.String())
+
+	policy, err := c.getPolicy(ctx, req)
+	if err != nil {
+		// The resource may no longer exist, in which case we stop processing.
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{Requeue: true}, err
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	policyv1alpha1.SetDefaultsOverridePolicy(policy)
+
+	deployList := &appsv1.DeploymentList{}
+	if err := c.Client.List(ctx, deployList, client.InNamespace(policy.Namespace)); err != nil {
+		klog.Errorf("failed to list deployments for %s, %v", utils.FormatPolicy("OverridePolicy", policy), err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	policies, err := utils.ListOverridePolicies(ctx, c.Client, policy.Namespace)
+	if err != nil {
+		klog.Errorf("failed to list override policies, %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	status := policyv1alpha1.OverridePolicyStatus{ObservedGeneration: policy.Generation}
+	for i := range deployList.Items {
+		deploy := &deployList.Items[i]
+		if !selectsDeployment(policy, deploy) {
+			continue
+		}
+		resource := policyv1alpha1.ResourceReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Namespace:  deploy.Namespace,
+			Name:       deploy.Name,
+		}
+		status.AppliedResources = append(status.AppliedResources, resource)
+		for _, conflict := range getConflicts(policy, policies, deploy) {
+			conflict.Resource = resource
+			status.Conflicts = append(status.Conflicts, conflict)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(policy.Status, status) {
+		return ctrl.Result{}, nil
+	}
+	newConflicts := len(status.Conflicts) > len(policy.Status.Conflicts)
+	policy.Status = status
+	obj := utils.OverridePolicyObject(policy)
+	if err := c.Client.Status().Update(ctx, obj); err != nil {
+		klog.Errorf("failed to update status of %s, %v", utils.FormatPolicy("OverridePolicy", policy), err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	if newConflicts {
+		for _, conflict := range status.Conflicts {
+			c.EventRecorder.Eventf(obj, corev1.EventTypeWarning, events.EventReasonOverrideConflict,
+				"overrider %q on %s %s/%s is overridden by %s", conflict.Overrider,
+				conflict.Resource.Kind, conflict.Resource.Namespace, conflict.Resource.Name, conflict.OverriddenBy)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// getPolicy gets the policy of the request. Requests without namespace are for ClusterOverridePolicies.
+func (c *Controller) getPolicy(ctx context.Context, req ctrl.Request) (*policyv1alpha1.OverridePolicy, error) {
+	if req.Namespace != "" {
+		policy := &policyv1alpha1.OverridePolicy{}
+		if err := c.Client.Get(ctx, req.NamespacedName, policy); err != nil {
+			return nil, err
+		}
+		return policy, nil
+	}
+
+	clusterPolicy := &policyv1alpha1.ClusterOverridePolicy{}
+	if err := c.Client.Get(ctx, req.NamespacedName, clusterPolicy); err != nil {
+		return nil, err
+	}
+	return utils.ConvertClusterOverridePolicy(clusterPolicy), nil
+}
+
+// selectsDeployment returns true if the policy selects the deployment. Policies without resource
+// selectors select all deployments in their namespace, or in all namespaces if they are cluster-scoped.
+func selectsDeployment(policy *policyv1alpha1.OverridePolicy, deploy *appsv1.Deployment) bool {
+	if len(policy.Spec.ResourceSelectors) == 0 {
+		return policy.Namespace == "" || policy.Namespace == deploy.Namespace
+	}
+	for _, selector := range policy.Spec.ResourceSelectors {
+		if selector.Kind != "Deployment" || selector.Namespace != deploy.Namespace {
+			continue
+		}
+		if selector.Name != "" {
+			if selector.Name == deploy.Name {
+				return true
+			}
+			continue
+		}
+		if selector.LabelSelector == nil {
+			return true
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			klog.Errorf("invalid label selector of %s, %v", utils.FormatPolicy("OverridePolicy", policy), err)
+			continue
+		}
+		if labelSelector.Matches(labels.Set(deploy.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getConflicts returns the overriders of the policy which conflict with overriders of policies
+// taking precedence over it on the deployment. Policies are sorted by precedence.
+func getConflicts(policy *policyv1alpha1.OverridePolicy, policies []policyv1alpha1.OverridePolicy, deploy *appsv1.Deployment) []policyv1alpha1.OverrideConflict {
+	conflicts := []policyv1alpha1.OverrideConflict{}
+	shadowed := sets.NewString()
+	for i := range policies {
+		other := &policies[i]
+		if utils.IsSamePolicy(other, policy) {
+			// remaining policies have lower precedence
+			break
+		}
+		if !selectsDeployment(other, deploy) {
+			continue
+		}
+		for _, rule := range policy.Spec.OverrideRules {
+			for _, otherRule := range other.Spec.OverrideRules {
+				if !targetsIntersect(rule.TargetNodeGroup, otherRule.TargetNodeGroup) {
+					continue
+				}
+				otherKeys := sets.NewString(overriderKeys(otherRule.Overriders)...)
+				for _, key := range overriderKeys(rule.Overriders) {
+					if otherKeys.Has(key) && !shadowed.Has(key) {
+						shadowed.Insert(key)
+						conflicts = append(conflicts, policyv1alpha1.OverrideConflict{
+							Overrider:    key,
+							OverriddenBy: utils.FormatPolicy("OverridePolicy", other),
+						})
+					}
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// overriderKeys returns keys identifying the fields modified by the overriders.
+// Overriders with the same key conflict with each other.
+func overriderKeys(overriders policyv1alpha1.Overriders) []string {
+	keys := []string{}
+	for _, overrider := range overriders.Plaintext {
+		keys = append(keys, fmt.Sprintf("plaintext %s", overrider.Path))
+	}
+	for _, overrider := range overriders.ImageOverrider {
+		key := fmt.Sprintf("image %s", overrider.Component)
+		if overrider.Predicate != nil {
+			key = fmt.Sprintf("%s %s", key, overrider.Predicate.Path)
+		}
+		keys = append(keys, key)
+	}
+	for _, overrider := range overriders.CommandOverrider {
+		keys = append(keys, fmt.Sprintf("command %s", overrider.ContainerName))
+	}
+	for _, overrider := range overriders.ArgsOverrider {
+		keys = append(keys, fmt.Sprintf("args %s", overrider.ContainerName))
+	}
+	return keys
+}
+
+// targetsIntersect returns true if both target nodegroup lists share a nodegroup.
+func targetsIntersect(a, b []string) bool {
+	setA, setB := sets.NewString(a...), sets.NewString(b...)
+	if setA.Has(policyv1alpha1.AllNodeGroups) || setB.Has(policyv1alpha1.AllNodeGroups) {
+		return true
+	}
+	return setA.HasAny(b...)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&policyv1alpha1.OverridePolicy{}).
+		// ClusterOverridePolicies are enqueued with requests without namespace.
+		Watches(&source.Kind{Type: &policyv1alpha1.ClusterOverridePolicy{}}, &handler.EnqueueRequestForObject{}).
+		// watch changes of policies and enqueue other policies, whose conflicts
+		// may change with the policy.
+		Watches(&source.Kind{Type: &policyv1alpha1.OverridePolicy{}},
+			handler.EnqueueRequestsFromMapFunc(c.newPolicyMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &policyv1alpha1.ClusterOverridePolicy{}},
+			handler.EnqueueRequestsFromMapFunc(c.newPolicyMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// watch changes of deployments and enqueue policies in their namespace
+		// when they are created, deleted or relabeled.
+		Watches(&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(c.newDeploymentMapFunc),
+			builder.WithPredicates(deploymentPredicate)).
+		Complete(c)
+}
+
+var deploymentPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+func (c *Controller) newPolicyMapFunc(obj client.Object) []ctrl.Request {
+	policies, err := utils.ListOverridePolicies(context.TODO(), c.Client, obj.GetNamespace())
+	if err != nil {
+		klog.Errorf("failed to list override policies, %v", err)
+		return nil
+	}
+	results := []ctrl.Request{}
+	for i := range policies {
+		if !utils.IsSamePolicy(&policies[i], obj) {
+			results = append(results, requestForPolicy(&policies[i]))
+		}
+	}
+	return results
+}
+
+func (c *Controller) newDeploymentMapFunc(obj client.Object) []ctrl.Request {
+	policies, err := utils.ListOverridePolicies(context.TODO(), c.Client, obj.GetNamespace())
+	if err != nil {
+		klog.Errorf("failed to list override policies, %v", err)
+		return nil
+	}
+	results := []ctrl.Request{}
+	for i := range policies {
+		results = append(results, requestForPolicy(&policies[i]))
+	}
+	return results
+}
+
+func requestForPolicy(policy *policyv1alpha1.OverridePolicy) ctrl.Request {
+	return ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name},
+	}
+}
@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+// getPolicy gets the policy of the request. Requests without namespace are for ClusterPropagationPolicies.
+func (p *Controller) getPolicy(ctx context.Context, req ctrl.Request) (*policyv1alpha1.PropagationPolicy, error) {
+	if req.Namespace != "" {
+		policy := &policyv1alpha1.PropagationPolicy{}
+		if err := p.Client.Get(ctx, req.NamespacedName, policy); err != nil {
+			return nil, err
+		}
+		return policy, nil
+	}
+
+	clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{}
+	if err := p.Client.Get(ctx, req.NamespacedName, clusterPolicy); err != nil {
+		return nil, err
+	}
+	return utils.ConvertClusterPropagationPolicy(clusterPolicy), nil
+}
+
+// updatePolicy updates the object holding the policy.
+func (p *Controller) updatePolicy(ctx context.Context, policy *policyv1alpha1.PropagationPolicy) error {
+	obj := utils.PropagationPolicyObject(policy)
+	if err := p.Client.Update(ctx, obj); err != nil {
+		return err
+	}
+	refreshPolicyMeta(policy, obj)
+	return nil
+}
+
+// refreshPolicyMeta copies the metadata of the updated object holding the policy back to the policy,
+// so that the policy can be updated again with the new resource version.
+func refreshPolicyMeta(policy *policyv1alpha1.PropagationPolicy, obj client.Object) {
+	if clusterPolicy, ok := obj.(*policyv1alpha1.ClusterPropagationPolicy); ok {
+		policy.ObjectMeta = clusterPolicy.ObjectMeta
+	}
+}
+
+// filterPropagatedDeploys returns the deploys which are propagated by the policy, filtering out
+// those selected by policies with higher precedence. The filtered out deploys are also returned
+// as messages describing which policies propagate them.
+func (p *Controller) filterPropagatedDeploys(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploys []*appsv1.Deployment) ([]*appsv1.Deployment, []string, error) {
+	results := make([]*appsv1.Deployment, 0, len(deploys))
+	shadowed := []string{}
+	for _, deploy := range deploys {
+		owner, err := utils.GetPolicyOfWorkload(ctx, p.Client, deploy)
+		if err != nil {
+			return deploys, nil, err
+		}
+		if owner != nil && !utils.IsSamePolicy(owner, policy) {
+			klog.V(2).Infof("deployment %s/%s selected by %s is propagated by %s", deploy.Namespace, deploy.Name,
+				utils.FormatPolicy("PropagationPolicy", policy), utils.FormatPolicy("PropagationPolicy", owner))
+			shadowed = append(shadowed, fmt.Sprintf("deployment %s/%s is propagated by %s",
+				deploy.Namespace, deploy.Name, utils.FormatPolicy("PropagationPolicy", owner)))
+			continue
+		}
+		results = append(results, deploy)
+	}
+	return results, shadowed, nil
+}
+
+// listPolicies lists PropagationPolicies in all namespaces and ClusterPropagationPolicies.
+func (p *Controller) listPolicies(ctx context.Context) ([]policyv1alpha1.PropagationPolicy, error) {
+	return utils.ListPropagationPolicies(ctx, p.Client, "")
+}
+
+// newPolicyOverlapMapFunc enqueues other policies selecting the same workloads as the policy,
+// because which policy propagates a workload changes with the precedence when the policy changes.
+func (p *Controller) newPolicyOverlapMapFunc(obj client.Object) []ctrl.Request {
+	var policy *policyv1alpha1.PropagationPolicy
+	switch o := obj.(type) {
+	case *policyv1alpha1.PropagationPolicy:
+		policy = o.DeepCopy()
+	case *policyv1alpha1.ClusterPropagationPolicy:
+		policy = utils.ConvertClusterPropagationPolicy(o)
+	default:
+		return nil
+	}
+	policyv1alpha1.SetDefaultsPropagationPolicy(policy)
+
+	policies, err := p.listPolicies(context.TODO())
+	if err != nil {
+		klog.Errorf("failed to list propagation policies, %v", err)
+		return nil
+	}
+	results := []ctrl.Request{}
+	for i := range policies {
+		other := &policies[i]
+		if !utils.IsSamePolicy(other, policy) && selectsSameWorkload(policy.Spec.ResourceSelectors, other.Spec.ResourceSelectors) {
+			results = append(results, ctrl.Request{
+				NamespacedName: types.NamespacedName{Namespace: other.Namespace, Name: other.Name},
+			})
+		}
+	}
+	return results
+}
+
+// selectsSameWorkload returns true if both resource selectors may select a same workload. Selectors
+// without names are assumed to overlap with any selector in the same namespace.
+func selectsSameWorkload(a, b []policyv1alpha1.ResourceSelector) bool {
+	for i := range a {
+		for j := range b {
+			if a[i].Namespace != b[j].Namespace {
+				continue
+			}
+			if a[i].Name == "" || b[j].Name == "" || a[i].Name == b[j].Name {
+				return true
+			}
+		}
+	}
+	return false
+}
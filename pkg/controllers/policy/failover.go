@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+const (
+	// defaultFailoverTolerationSeconds is the default time all nodes of a nodegroup can be not ready
+	// before the nodegroup is failed over.
+	defaultFailoverTolerationSeconds = 300
+	// defaultFailoverRecoverySeconds is the default time a failed over nodegroup must be ready again
+	// before its share of pods is restored.
+	defaultFailoverRecoverySeconds = 300
+)
+
+// Reasons of the FailedOver condition.
+const (
+	reasonNodeGroupsOffline = "NodeGroupsOffline"
+	reasonNodeGroupsOnline  = "NodeGroupsOnline"
+)
+
+// syncFailover returns the target nodegroups of the policy which should be failed over now, and
+// the duration after which the failover should be checked again, which is 0 if nothing is pending.
+// A nodegroup is failed over when all of its nodes have been not ready for the toleration period,
+// and stays failed over until it has been ready again for the recovery period.
+func (p *Controller) syncFailover(policy *policyv1alpha1.PropagationPolicy, groups []nodegroupv1alpha1.NodeGroup, now time.Time) ([]policyv1alpha1.FailedOverNodeGroup, time.Duration) {
+	if !policy.Spec.Failover.Enabled {
+		return nil, 0
+	}
+	toleration := getSecondsOrDefault(policy.Spec.Failover.TolerationSeconds, defaultFailoverTolerationSeconds)
+	recovery := getSecondsOrDefault(policy.Spec.Failover.RecoverySeconds, defaultFailoverRecoverySeconds)
+
+	groupMap := make(map[string]*nodegroupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+	previous := make(map[string]policyv1alpha1.FailedOverNodeGroup, len(policy.Status.FailedOverNodeGroups))
+	for _, group := range policy.Status.FailedOverNodeGroups {
+		previous[group.Name] = group
+	}
+
+	var failedOver []policyv1alpha1.FailedOverNodeGroup
+	var requeueAfter time.Duration
+	checkLater := func(remaining time.Duration) {
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	for _, name := range utils.GetTargetNodeGroupNames(policy) {
+		group, ok := groupMap[name]
+		if !ok {
+			// missing nodegroups are reported by checkTargetNodeGroups
+			continue
+		}
+
+		if failedOverGroup, ok := previous[name]; ok {
+			if online, since := isNodeGroupOnline(group); online {
+				if remaining := recovery - now.Sub(since); remaining > 0 {
+					checkLater(remaining)
+				} else {
+					klog.Infof("nodegroup %s of policy %s/%s has recovered", name, policy.Namespace, policy.Name)
+					p.EventRecorder.Eventf(utils.PropagationPolicyObject(policy), corev1.EventTypeNormal, events.EventReasonNodeGroupRecovered,
+						"NodeGroup %s is online again, restore its share of pods", name)
+					continue
+				}
+			}
+			failedOver = append(failedOver, failedOverGroup)
+			continue
+		}
+
+		offline, since := isNodeGroupOffline(group)
+		if !offline {
+			continue
+		}
+		if remaining := toleration - now.Sub(since); remaining > 0 {
+			checkLater(remaining)
+			continue
+		}
+		klog.Infof("nodegroup %s of policy %s/%s is offline, fail it over", name, policy.Namespace, policy.Name)
+		p.EventRecorder.Eventf(utils.PropagationPolicyObject(policy), corev1.EventTypeWarning, events.EventReasonNodeGroupFailedOver,
+			"All nodes of NodeGroup %s have been not ready for %v, redistribute its share of pods to other nodegroups", name, toleration)
+		failedOver = append(failedOver, policyv1alpha1.FailedOverNodeGroup{
+			Name:           name,
+			FailedOverTime: metav1.NewTime(now),
+		})
+	}
+	return failedOver, requeueAfter
+}
+
+// setFailoverStatus sets the failed over nodegroups and the FailedOver condition in the status.
+func setFailoverStatus(status *policyv1alpha1.PropagationPolicyStatus, policy *policyv1alpha1.PropagationPolicy, failedOver []policyv1alpha1.FailedOverNodeGroup) {
+	status.FailedOverNodeGroups = failedOver
+	if !policy.Spec.Failover.Enabled {
+		meta.RemoveStatusCondition(&status.Conditions, policyv1alpha1.FailedOver)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               policyv1alpha1.FailedOver,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: policy.Generation,
+		Reason:             reasonNodeGroupsOnline,
+		Message:            "No target nodegroup is failed over",
+	}
+	if len(failedOver) != 0 {
+		names := make([]string, 0, len(failedOver))
+		for _, group := range failedOver {
+			names = append(names, group.Name)
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonNodeGroupsOffline
+		condition.Message = fmt.Sprintf("Pods of offline nodegroups %v are redistributed to other target nodegroups", names)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// isNodeGroupOffline returns true if the nodegroup has nodes but none of them is ready,
+// along with the time since when its last ready node became not ready. Nodes which are
+// ready but unschedulable do not make the nodegroup offline.
+func isNodeGroupOffline(group *nodegroupv1alpha1.NodeGroup) (bool, time.Time) {
+	if group.Status.TotalNodes == 0 || group.Status.ReadyNodes != 0 {
+		return false, time.Time{}
+	}
+	condition := meta.FindStatusCondition(group.Status.Conditions, nodegroupv1alpha1.NodeGroupOffline)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return false, time.Time{}
+	}
+	return true, condition.LastTransitionTime.Time
+}
+
+// isNodeGroupOnline returns true if some nodes of the nodegroup are ready, along with the time
+// since when it is no longer offline.
+func isNodeGroupOnline(group *nodegroupv1alpha1.NodeGroup) (bool, time.Time) {
+	if group.Status.ReadyNodes == 0 {
+		return false, time.Time{}
+	}
+	condition := meta.FindStatusCondition(group.Status.Conditions, nodegroupv1alpha1.NodeGroupOffline)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		return false, time.Time{}
+	}
+	return true, condition.LastTransitionTime.Time
+}
+
+func getSecondsOrDefault(seconds *int32, defaultSeconds int32) time.Duration {
+	if seconds == nil {
+		return time.Duration(defaultSeconds) * time.Second
+	}
+	return time.Duration(*seconds) * time.Second
+}
@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+// newOfflineTestNodeGroup returns a nodegroup with 2 nodes, which has been offline or
+// online since the given time.
+func newOfflineTestNodeGroup(name string, offline bool, since time.Time) nodegroupv1alpha1.NodeGroup {
+	group := nodegroupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	group.Status.TotalNodes = 2
+	group.Status.ReadyNodes = 2
+	status := metav1.ConditionFalse
+	if offline {
+		group.Status.ReadyNodes = 0
+		status = metav1.ConditionTrue
+	}
+	group.Status.Conditions = []metav1.Condition{{
+		Type:               nodegroupv1alpha1.NodeGroupOffline,
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(since),
+	}}
+	return group
+}
+
+func TestSyncFailover(t *testing.T) {
+	toleration := int32(60)
+	recovery := int32(120)
+	failedOverTime := metav1.NewTime(testTime.Add(-time.Hour))
+
+	cases := []struct {
+		name             string
+		disabled         bool
+		group            nodegroupv1alpha1.NodeGroup
+		previous         []policyv1alpha1.FailedOverNodeGroup
+		wantFailedOver   []policyv1alpha1.FailedOverNodeGroup
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:  "online nodegroup",
+			group: newOfflineTestNodeGroup("beijing", false, testTime.Add(-time.Hour)),
+		},
+		{
+			name:             "offline within toleration",
+			group:            newOfflineTestNodeGroup("beijing", true, testTime.Add(-20*time.Second)),
+			wantRequeueAfter: 40 * time.Second,
+		},
+		{
+			name:           "offline beyond toleration",
+			group:          newOfflineTestNodeGroup("beijing", true, testTime.Add(-time.Minute)),
+			wantFailedOver: []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: metav1.NewTime(testTime)}},
+		},
+		{
+			name: "ready but unschedulable nodegroup is not offline",
+			group: func() nodegroupv1alpha1.NodeGroup {
+				group := newOfflineTestNodeGroup("beijing", false, testTime.Add(-time.Hour))
+				group.Status.UnschedulableNodes = 2
+				return group
+			}(),
+		},
+		{
+			name: "empty nodegroup is not offline",
+			group: func() nodegroupv1alpha1.NodeGroup {
+				group := newOfflineTestNodeGroup("beijing", false, testTime.Add(-time.Hour))
+				group.Status.TotalNodes = 0
+				group.Status.ReadyNodes = 0
+				return group
+			}(),
+		},
+		{
+			name:           "failed over nodegroup stays offline",
+			group:          newOfflineTestNodeGroup("beijing", true, testTime.Add(-2*time.Hour)),
+			previous:       []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: failedOverTime}},
+			wantFailedOver: []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: failedOverTime}},
+		},
+		{
+			name:             "failed over nodegroup online within recovery",
+			group:            newOfflineTestNodeGroup("beijing", false, testTime.Add(-time.Minute)),
+			previous:         []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: failedOverTime}},
+			wantFailedOver:   []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: failedOverTime}},
+			wantRequeueAfter: time.Minute,
+		},
+		{
+			name:     "failed over nodegroup recovered",
+			group:    newOfflineTestNodeGroup("beijing", false, testTime.Add(-2*time.Minute)),
+			previous: []policyv1alpha1.FailedOverNodeGroup{{Name: "beijing", FailedOverTime: failedOverTime}},
+		},
+		{
+			name:     "failover disabled",
+			disabled: true,
+			group:    newOfflineTestNodeGroup("beijing", true, testTime.Add(-time.Hour)),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := &policyv1alpha1.PropagationPolicy{}
+			policy.Spec.Placement.StaticWeightList = []policyv1alpha1.StaticNodeGroupWeight{
+				{NodeGroupNames: []string{"beijing"}, Weight: 1},
+				{NodeGroupNames: []string{"hangzhou"}, Weight: 1},
+			}
+			policy.Spec.Failover = policyv1alpha1.FailoverPolicy{
+				Enabled:           !c.disabled,
+				TolerationSeconds: &toleration,
+				RecoverySeconds:   &recovery,
+			}
+			policy.Status.FailedOverNodeGroups = c.previous
+			groups := []nodegroupv1alpha1.NodeGroup{
+				c.group,
+				newOfflineTestNodeGroup("hangzhou", false, testTime.Add(-time.Hour)),
+			}
+
+			p := &Controller{EventRecorder: record.NewFakeRecorder(10)}
+			failedOver, requeueAfter := p.syncFailover(policy, groups, testTime)
+			if !reflect.DeepEqual(failedOver, c.wantFailedOver) {
+				t.Errorf("want failed over nodegroups %v, got %v", c.wantFailedOver, failedOver)
+			}
+			if requeueAfter != c.wantRequeueAfter {
+				t.Errorf("want requeue after %v, got %v", c.wantRequeueAfter, requeueAfter)
+			}
+		})
+	}
+}
@@ -18,25 +18,70 @@ package policy
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
 	"github.com/Congrool/nodes-grouping/pkg/utils"
 )
 
-// Controller reconciles a PropagationPolicy object
+const (
+	// ControllerName is the controller name that will be used when reporting events.
+	ControllerName = "propagationpolicy-controller"
+	// PolicyFinalizer is the finalizer added to PropagationPolicies and ClusterPropagationPolicies to restore
+	// workloads before they are deleted.
+	PolicyFinalizer = "policy.kubeedge.io/propagationpolicy-controller"
+	// restartedAtAnnotation is the pod template annotation used to trigger a rolling restart,
+	// which is the same as the one used by "kubectl rollout restart".
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// Reasons of the NodeGroupsAvailable condition.
+const (
+	reasonNodeGroupsAvailable = "NodeGroupsAvailable"
+	reasonNodeGroupNotFound   = "NodeGroupNotFound"
+	reasonNodeGroupDeleting   = "NodeGroupDeleting"
+	reasonNodeGroupEmpty      = "NodeGroupEmpty"
+)
+
+// Reasons of the Shadowed condition.
+const (
+	reasonNotShadowed        = "NotShadowed"
+	reasonShadowedByPolicies = "ShadowedByPolicies"
+)
+
+// Controller reconciles a PropagationPolicy object. ClusterPropagationPolicies are reconciled as
+// PropagationPolicies without namespace, see utils.ConvertClusterPropagationPolicy.
 type Controller struct {
 	client.Client
+	// KubeClient is used to evict pods through the Eviction API.
+	KubeClient    kubernetes.Interface
+	EventRecorder record.EventRecorder
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -45,16 +90,27 @@ type Controller struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.9.2/pkg/reconcile
 func (p *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	policy := &policyv1alpha1.PropagationPolicy{}
-	if err := p.Client.Get(ctx, req.NamespacedName, policy); err != nil {
-		if apierrors.IsNotFound(err) || policy.DeletionTimestamp != nil {
-			// TODO: handle delete event
-			// currently do nothing, leave the scheduled pods as they are.
-			klog.Infof("policy %s/%s has been deleted or is to be deleted, skip reconcil", policy.Namespace, policy.Name)
+	policy, err := p.getPolicy(ctx, req)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Infof("policy %s has been deleted, skip reconcile", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{Requeue: true}, err
 	}
+	policyv1alpha1.SetDefaultsPropagationPolicy(policy)
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return p.removePolicy(ctx, policy)
+	}
+
+	if !controllerutil.ContainsFinalizer(policy, PolicyFinalizer) {
+		controllerutil.AddFinalizer(policy, PolicyFinalizer)
+		if err := p.updatePolicy(ctx, policy); err != nil {
+			klog.Errorf("failed to add finalizer to policy %s/%s, %v", policy.Namespace, policy.Name, err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
 	klog.Infof("reconciling policy %s/%s", policy.Namespace, policy.Name)
 
 	nodegroupList := &nodegroupv1alpha1.NodeGroupList{}
@@ -63,82 +119,260 @@ func (p *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
-	nodesInNodeGroups, err := utils.GetNodesInGroups(ctx, p.Client, nodegroupList.Items)
+	// target nodegroups of the policy take precedence over others when a node belongs to multiple nodegroups
+	nodesInNodeGroups, err := utils.GetNodesInGroups(ctx, p.Client, utils.SortNodeGroupsByPolicy(nodegroupList.Items, policy))
 	if err != nil {
 		klog.Errorf("failed to get nodes in nodegroups, err: %v", err)
 		return ctrl.Result{}, nil
 	}
 	klog.V(2).Infof("get nodes in nodegroups: %v", nodesInNodeGroups)
 
+	failedOver, failoverRequeueAfter := p.syncFailover(policy, nodegroupList.Items, time.Now())
+	// pods are placed without the nodegroups failed over now
+	placementPolicy := policy.DeepCopy()
+	placementPolicy.Status.FailedOverNodeGroups = failedOver
+
 	// TODO:
 	// Currently, only support selecting deploys with their namespace and name.
 	// More approaches are needed.
 	deploys, err := utils.GetManifestsDeploys(ctx, p.Client, policy)
+	deploys, shadowed, precedenceErr := p.filterPropagatedDeploys(ctx, policy, deploys)
+	if precedenceErr != nil {
+		err = errors.NewAggregate([]error{err, precedenceErr})
+	}
+	nodeGroupsCondition := p.checkTargetNodeGroups(policy, nodegroupList.Items, deploys)
+	shadowedCondition := newShadowedCondition(policy, shadowed)
 	if err != nil {
 		klog.Warningf("failed to get some deploys manifested by policy %s/%s, %v, reconcile it later", policy.Namespace, policy.Name, err)
+		status := policyv1alpha1.PropagationPolicyStatus{
+			ObservedGeneration: policy.Generation,
+			MatchedWorkloads:   int32(len(deploys)),
+			BalanceState:       policyv1alpha1.BalanceUnknown,
+			Conditions:         policy.Status.DeepCopy().Conditions,
+		}
+		meta.SetStatusCondition(&status.Conditions, nodeGroupsCondition)
+		if precedenceErr == nil {
+			// keep the last Shadowed condition if the precedence is unknown
+			meta.SetStatusCondition(&status.Conditions, shadowedCondition)
+		}
+		setFailoverStatus(&status, policy, failedOver)
+		if err := p.updateStatus(ctx, policy, status); err != nil {
+			klog.Errorf("failed to update status of policy %s/%s, %v", policy.Namespace, policy.Name, err)
+		}
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	status := policyv1alpha1.PropagationPolicyStatus{
+		ObservedGeneration: policy.Generation,
+		MatchedWorkloads:   int32(len(deploys)),
+		BalanceState:       policyv1alpha1.Balanced,
+		Conditions:         policy.Status.DeepCopy().Conditions,
+	}
+	meta.SetStatusCondition(&status.Conditions, nodeGroupsCondition)
+	meta.SetStatusCondition(&status.Conditions, shadowedCondition)
+	setFailoverStatus(&status, policy, failedOver)
+	result := ctrl.Result{RequeueAfter: failoverRequeueAfter}
 	errs := []error{}
 	for _, deploy := range deploys {
 		klog.Infof("get deploy %s/%s manifested by policy %s/%s", deploy.Namespace, deploy.Name, policy.Namespace, policy.Name)
+		if err := p.bindWorkload(ctx, policy, deploy); err != nil {
+			klog.Errorf("failed to record policy %s/%s on deployment %s/%s, %v", policy.Namespace, policy.Name, deploy.Namespace, deploy.Name, err)
+			errs = append(errs, err)
+		}
 		podList, err := utils.GetPodListFromDeploy(ctx, p.Client, deploy)
 		if err != nil {
 			klog.Errorf("failed to get pod list of deployment %s/%s, %v", deploy.Namespace, deploy.Name, err)
+			status.BalanceState = policyv1alpha1.BalanceUnknown
 			continue
 		}
+
+		desiredPodsNumOfEachNodeGroup := utils.DesiredPodsNumOfPolicy(placementPolicy, nodegroupList.Items, *deploy.Spec.Replicas)
+		workloadStatus := newWorkloadPlacementStatus(deploy, desiredPodsNumOfEachNodeGroup, podList.Items, nodesInNodeGroups)
+		status.Workloads = append(status.Workloads, workloadStatus)
+		if status.BalanceState == policyv1alpha1.Balanced && !isWorkloadBalanced(workloadStatus) {
+			status.BalanceState = policyv1alpha1.Unbalanced
+		}
+
 		if len(podList.Items) == 0 {
 			klog.Infof("get no pod for deploy %s/%s", deploy.Namespace, deploy.Name)
 			continue
 		}
 
-		desiredPodsNumOfEachNodeGroup := utils.DesiredPodsNumInTargetNodeGroups(policy.Spec.Placement.StaticWeightList, *deploy.Spec.Replicas)
-		deletePods := getPodsNeedToDelete(podList.Items, desiredPodsNumOfEachNodeGroup, nodesInNodeGroups)
-		for _, pod := range deletePods {
-			klog.Infof("deleting pod %s/%s", pod.Namespace, pod.Name)
-			if err := p.Client.Delete(ctx, &pod); err != nil && apierrors.IsNotFound(err) {
-				klog.Errorf("failed to delete pod %s/%s, %v", pod.Namespace, pod.Name, err)
-				errs = append(errs, err)
-			}
+		pending, err := p.rebalanceWorkload(ctx, policy, deploy, podList.Items, desiredPodsNumOfEachNodeGroup, nodesInNodeGroups, nodegroupList.Items)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if pending && (result.RequeueAfter == 0 || rebalanceRetryPeriod < result.RequeueAfter) {
+			// replacements are not ready yet or evictions are blocked
+			result.RequeueAfter = rebalanceRetryPeriod
 		}
 	}
 
-	return ctrl.Result{}, errors.NewAggregate(errs)
+	if err := p.updateStatus(ctx, policy, status); err != nil {
+		klog.Errorf("failed to update status of policy %s/%s, %v", policy.Namespace, policy.Name, err)
+		errs = append(errs, err)
+	}
+
+	return result, errors.NewAggregate(errs)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (p *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&policyv1alpha1.PropagationPolicy{}).
+		// ClusterPropagationPolicies are enqueued with requests without namespace.
+		Watches(&source.Kind{Type: &policyv1alpha1.ClusterPropagationPolicy{}}, &handler.EnqueueRequestForObject{}).
+		// watch changes of policies and enqueue other policies selecting the same workloads,
+		// whose precedence over the workloads may change.
+		Watches(&source.Kind{Type: &policyv1alpha1.PropagationPolicy{}},
+			handler.EnqueueRequestsFromMapFunc(p.newPolicyOverlapMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &policyv1alpha1.ClusterPropagationPolicy{}},
+			handler.EnqueueRequestsFromMapFunc(p.newPolicyOverlapMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		// watch changes of NodeGroup and enqueue relavent policies
 		// when nodes in node group has changed.
 		Watches(&source.Kind{Type: &nodegroupv1alpha1.NodeGroup{}}, handler.EnqueueRequestsFromMapFunc(p.newNodeGroupMapFunc)).
-		// TODO:
-		// watch deployment and reconcile when replicas descreases.
+		// watch changes of deployments and enqueue policies selecting them
+		// when they are scaled.
+		Watches(&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(p.newDeploymentMapFunc),
+			builder.WithPredicates(deploymentPredicate)).
+		// watch changes of pods and enqueue policies selecting their deployments
+		// when they are scheduled, fail or migrate to other nodes.
+		Watches(&source.Kind{Type: &corev1.Pod{}},
+			handler.EnqueueRequestsFromMapFunc(p.newPodMapFunc),
+			builder.WithPredicates(podPredicate)).
 		Complete(p)
 }
 
+func (p *Controller) newDeploymentMapFunc(obj client.Object) []ctrl.Request {
+	policies, err := p.listPolicies(context.TODO())
+	if err != nil {
+		klog.Errorf("failed to list propagation policies, %v", err)
+		return nil
+	}
+
+	results := []ctrl.Request{}
+	for i := range policies {
+		policy := &policies[i]
+		if utils.SelectsWorkload(policy.Spec.ResourceSelectors, obj) {
+			results = append(results, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: policy.Namespace,
+					Name:      policy.Name,
+				}})
+		}
+	}
+	return results
+}
+
+// newPodMapFunc enqueues policies selecting the deployment controlling the pod.
+func (p *Controller) newPodMapFunc(obj client.Object) []ctrl.Request {
+	pod := obj.(*corev1.Pod)
+	owner, err := utils.GetPodOwner(context.TODO(), p.Client, pod)
+	if err != nil {
+		klog.Errorf("failed to get owner of pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	deploy, ok := owner.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	return p.newDeploymentMapFunc(deploy)
+}
+
+// deploymentPredicate filters out deployment updates which cannot change the distribution of pods.
+var deploymentPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldDeploy, ok := e.ObjectOld.(*appsv1.Deployment)
+		if !ok {
+			return false
+		}
+		newDeploy, ok := e.ObjectNew.(*appsv1.Deployment)
+		if !ok {
+			return false
+		}
+		// labels decide which policies select the deployment by labelSelector
+		return !equality.Semantic.DeepEqual(oldDeploy.Spec.Replicas, newDeploy.Spec.Replicas) ||
+			!equality.Semantic.DeepEqual(oldDeploy.Spec.Selector, newDeploy.Spec.Selector) ||
+			!equality.Semantic.DeepEqual(oldDeploy.Labels, newDeploy.Labels)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+// podPredicate filters out pod updates which cannot change the distribution of pods.
+var podPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		pod, ok := e.Object.(*corev1.Pod)
+		return ok && pod.Spec.NodeName != ""
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, ok := e.ObjectOld.(*corev1.Pod)
+		if !ok {
+			return false
+		}
+		newPod, ok := e.ObjectNew.(*corev1.Pod)
+		if !ok {
+			return false
+		}
+		return oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+			oldPod.Status.Phase != newPod.Status.Phase ||
+			isPodReady(oldPod) != isPodReady(newPod) ||
+			(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+// isPodReady returns true if the Ready condition of the pod is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func (p *Controller) newNodeGroupMapFunc(obj client.Object) []ctrl.Request {
 	groupobj := obj.(*nodegroupv1alpha1.NodeGroup)
-	policyList := &policyv1alpha1.PropagationPolicyList{}
-	if err := p.Client.List(context.TODO(), policyList); err != nil {
-		klog.Errorf("failed to list propagation policy, %v", err)
+	policies, err := p.listPolicies(context.TODO())
+	if err != nil {
+		klog.Errorf("failed to list propagation policies, %v", err)
+		return nil
+	}
+
+	// child nodegroups of target nodegroups are needed to find policies splitting pods by them
+	groupList := &nodegroupv1alpha1.NodeGroupList{}
+	if err := p.Client.List(context.TODO(), groupList); err != nil {
+		klog.Errorf("failed to list nodegroups, %v", err)
 		return nil
 	}
 
 	results := []ctrl.Request{}
 
 	forEachPolicyDo := func(fn func(*policyv1alpha1.PropagationPolicy)) {
-		for i := range policyList.Items {
-			fn(&policyList.Items[i])
+		for i := range policies {
+			fn(&policies[i])
 		}
 	}
 	ifNodeGroupInPolicy := func(policy *policyv1alpha1.PropagationPolicy) bool {
-		for _, weight := range policy.Spec.Placement.StaticWeightList {
-			for _, group := range weight.NodeGroupNames {
-				if group == groupobj.Name {
-					return true
-				}
+		for _, group := range utils.GetPlacementNodeGroupNames(policy, groupList.Items) {
+			if group == groupobj.Name {
+				return true
 			}
 		}
 		return false
@@ -157,22 +391,293 @@ func (p *Controller) newNodeGroupMapFunc(obj client.Object) []ctrl.Request {
 	return results
 }
 
-func getPodsNeedToDelete(pods []corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string) []corev1.Pod {
-	deletePod := []corev1.Pod{}
-	count := make(map[string]int32)
+// checkTargetNodeGroups returns the NodeGroupsAvailable condition of the policy according to target
+// nodegroups which do not exist, are being deleted or contain no node. Events about missing and empty
+// nodegroups are recorded only when the condition changes.
+func (p *Controller) checkTargetNodeGroups(policy *policyv1alpha1.PropagationPolicy, groups []nodegroupv1alpha1.NodeGroup, deploys []*appsv1.Deployment) metav1.Condition {
+	existingGroups := make(map[string]*nodegroupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		existingGroups[groups[i].Name] = &groups[i]
+	}
 
+	var missing, deleting, empty []string
+	for _, name := range utils.GetTargetNodeGroupNames(policy) {
+		group, ok := existingGroups[name]
+		switch {
+		case !ok:
+			missing = append(missing, name)
+		case group.DeletionTimestamp != nil:
+			deleting = append(deleting, name)
+		case group.Status.TotalNodes == 0:
+			empty = append(empty, name)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               policyv1alpha1.NodeGroupsAvailable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             reasonNodeGroupsAvailable,
+		Message:            "All target nodegroups are available",
+	}
+	switch {
+	case len(missing) != 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNodeGroupNotFound
+		condition.Message = fmt.Sprintf("Target nodegroups %v do not exist", missing)
+	case len(deleting) != 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNodeGroupDeleting
+		condition.Message = fmt.Sprintf("Target nodegroups %v are being deleted, remove them from the policy to finish the deletion", deleting)
+	case len(empty) != 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNodeGroupEmpty
+		condition.Message = fmt.Sprintf("Target nodegroups %v contain no node", empty)
+	}
+
+	// only report the nodegroups when the condition changes, rather than on every reconcile
+	previous := meta.FindStatusCondition(policy.Status.Conditions, policyv1alpha1.NodeGroupsAvailable)
+	if previous != nil && previous.Status == condition.Status && previous.Reason == condition.Reason &&
+		previous.Message == condition.Message {
+		return condition
+	}
+	for _, name := range missing {
+		p.recordEvent(policy, deploys, corev1.EventTypeWarning, events.EventReasonNodeGroupNotFound,
+			"NodeGroup %s referenced by %s does not exist", name, utils.FormatPolicy("PropagationPolicy", policy))
+	}
+	for _, name := range empty {
+		p.recordEvent(policy, deploys, corev1.EventTypeWarning, events.EventReasonNodeGroupEmpty,
+			"NodeGroup %s referenced by %s contains no node", name, utils.FormatPolicy("PropagationPolicy", policy))
+	}
+	return condition
+}
+
+// newShadowedCondition returns the Shadowed condition of the policy. The messages describe workloads
+// selected by the policy but propagated by policies with higher precedence.
+func newShadowedCondition(policy *policyv1alpha1.PropagationPolicy, shadowed []string) metav1.Condition {
+	if len(shadowed) == 0 {
+		return metav1.Condition{
+			Type:               policyv1alpha1.Shadowed,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: policy.Generation,
+			Reason:             reasonNotShadowed,
+			Message:            "All selected workloads are propagated by the policy",
+		}
+	}
+	return metav1.Condition{
+		Type:               policyv1alpha1.Shadowed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             reasonShadowedByPolicies,
+		Message:            strings.Join(shadowed, "; "),
+	}
+}
+
+// recordEvent records the event on the policy and each of the deploys.
+func (p *Controller) recordEvent(policy *policyv1alpha1.PropagationPolicy, deploys []*appsv1.Deployment, eventtype, reason, messageFmt string, args ...interface{}) {
+	p.EventRecorder.Eventf(utils.PropagationPolicyObject(policy), eventtype, reason, messageFmt, args...)
+	for _, deploy := range deploys {
+		p.EventRecorder.Eventf(deploy, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// updateStatus updates the status of the policy if it has changed.
+func (p *Controller) updateStatus(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, status policyv1alpha1.PropagationPolicyStatus) error {
+	if equality.Semantic.DeepEqual(policy.Status, status) {
+		return nil
+	}
+	policy.Status = status
+	obj := utils.PropagationPolicyObject(policy)
+	if err := p.Client.Status().Update(ctx, obj); err != nil {
+		return err
+	}
+	refreshPolicyMeta(policy, obj)
+	return nil
+}
+
+// newWorkloadPlacementStatus counts the scheduled pods of the deploy in each target nodegroup.
+func newWorkloadPlacementStatus(deploy *appsv1.Deployment, desiredPods map[string]int32, pods []corev1.Pod, nodesInNodeGroups map[string]string) policyv1alpha1.WorkloadPlacementStatus {
+	currentPods := make(map[string]int32)
+	var strayPods int32
 	for _, pod := range pods {
-		if pod.Spec.NodeName == "" {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if isStrayPod(&pod, desiredPods, nodesInNodeGroups) {
+			strayPods++
+			continue
+		}
+		currentPods[nodesInNodeGroups[pod.Spec.NodeName]]++
+	}
+
+	groupNames := make([]string, 0, len(desiredPods))
+	for groupname := range desiredPods {
+		groupNames = append(groupNames, groupname)
+	}
+	sort.Strings(groupNames)
+
+	workloadStatus := policyv1alpha1.WorkloadPlacementStatus{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       "Deployment",
+		Namespace:  deploy.Namespace,
+		Name:       deploy.Name,
+		Replicas:   *deploy.Spec.Replicas,
+		StrayPods:  strayPods,
+	}
+	for _, groupname := range groupNames {
+		workloadStatus.NodeGroups = append(workloadStatus.NodeGroups, policyv1alpha1.NodeGroupPodsStatus{
+			Name:    groupname,
+			Desired: desiredPods[groupname],
+			Current: currentPods[groupname],
+		})
+	}
+	return workloadStatus
+}
+
+func isWorkloadBalanced(workloadStatus policyv1alpha1.WorkloadPlacementStatus) bool {
+	if workloadStatus.StrayPods != 0 {
+		return false
+	}
+	for _, group := range workloadStatus.NodeGroups {
+		if group.Current != group.Desired {
+			return false
+		}
+	}
+	return true
+}
+
+// removePolicy restores workloads selected by the policy to default scheduling before
+// the finalizer of the policy is removed.
+func (p *Controller) removePolicy(ctx context.Context, policy *policyv1alpha1.PropagationPolicy) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(policy, PolicyFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	klog.Infof("policy %s/%s is being deleted, restore its workloads", policy.Namespace, policy.Name)
+
+	errs := []error{}
+	namespaces := sets.NewString()
+	for _, selector := range policy.Spec.ResourceSelectors {
+		namespaces.Insert(selector.Namespace)
+	}
+	deploys := []*appsv1.Deployment{}
+	for _, namespace := range namespaces.List() {
+		deployList := &appsv1.DeploymentList{}
+		if err := p.Client.List(ctx, deployList, client.InNamespace(namespace)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list deployments in namespace %s, %v", namespace, err))
 			continue
 		}
-		if groupname, ok := nodesInNodeGroups[pod.Spec.NodeName]; ok {
-			count[groupname]++
-			if count[groupname] > desiredPods[groupname] {
-				// More than desired number of pods can run in this nodegroup
-				klog.V(2).Infof("pod %s/%s in nodegroup %s is no longer needed, add it to delete queue", pod.Namespace, pod.Name, groupname)
-				deletePod = append(deletePod, pod)
+		for i := range deployList.Items {
+			if utils.SelectsWorkload(policy.Spec.ResourceSelectors, &deployList.Items[i]) {
+				deploys = append(deploys, &deployList.Items[i])
 			}
 		}
 	}
-	return deletePod
+	for _, deploy := range deploys {
+		successor, err := utils.GetPolicyOfWorkload(ctx, p.Client, deploy)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if successor != nil {
+			klog.Infof("deployment %s/%s is taken over by %s, skip restoring it", deploy.Namespace, deploy.Name,
+				utils.FormatPolicy("PropagationPolicy", successor))
+			continue
+		}
+		if err := p.restoreWorkload(ctx, policy, deploy); err != nil {
+			klog.Errorf("failed to restore deployment %s/%s, %v", deploy.Namespace, deploy.Name, err)
+			p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeWarning, events.EventReasonRestoreWorkloadFailed,
+				"Failed to restore deployment %s/%s after %s is deleted, %v", deploy.Namespace, deploy.Name, utils.FormatPolicy("PropagationPolicy", policy), err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return ctrl.Result{Requeue: true}, errors.NewAggregate(errs)
+	}
+
+	controllerutil.RemoveFinalizer(policy, PolicyFinalizer)
+	if err := p.updatePolicy(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("failed to remove finalizer of policy %s/%s, %v", policy.Namespace, policy.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// restoreWorkload removes annotations of the policy group from the deployment, and from its pod template
+// if the policy annotates it, and restarts the deployment if the policy asks to. The pod template is
+// left unchanged otherwise, so that pods are not restarted. The deployment is restarted only if it is
+// still recorded with the policy, so that it is not restarted again when the deletion is retried.
+func (p *Controller) restoreWorkload(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploy *appsv1.Deployment) error {
+	bound := deploy.Annotations[policyv1alpha1.PropagationPolicyAnnotation] == utils.PolicyKey(policy)
+	updated := deploy.DeepCopy()
+	changed := removePolicyAnnotations(updated.Annotations)
+	if policy.Spec.AnnotatePodTemplate && removePolicyAnnotations(updated.Spec.Template.Annotations) {
+		changed = true
+	}
+	restart := policy.Spec.RestartWorkloadsOnDeletion && bound
+	if restart {
+		if updated.Spec.Template.Annotations == nil {
+			updated.Spec.Template.Annotations = map[string]string{}
+		}
+		updated.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := p.Client.Patch(ctx, updated, client.MergeFrom(deploy)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	message := fmt.Sprintf("Restored deployment %s/%s to default scheduling after %s is deleted",
+		deploy.Namespace, deploy.Name, utils.FormatPolicy("PropagationPolicy", policy))
+	if restart {
+		message += ", restarting its pods"
+	}
+	p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeNormal, events.EventReasonRestoreWorkload, "%s", message)
+	return nil
+}
+
+// bindWorkload records the policy in PropagationPolicyAnnotation of the deployment, so that the
+// scheduler extender finds the policy of pods through their deployment without checking all policies.
+// The pod template, whose change rolls out the deployment, is annotated only if the policy asks to.
+func (p *Controller) bindWorkload(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploy *appsv1.Deployment) error {
+	key := utils.PolicyKey(policy)
+	if deploy.Annotations[policyv1alpha1.PropagationPolicyAnnotation] == key &&
+		(!policy.Spec.AnnotatePodTemplate || deploy.Spec.Template.Annotations[policyv1alpha1.PropagationPolicyAnnotation] == key) {
+		return nil
+	}
+
+	updated := deploy.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[policyv1alpha1.PropagationPolicyAnnotation] = key
+	if policy.Spec.AnnotatePodTemplate {
+		if updated.Spec.Template.Annotations == nil {
+			updated.Spec.Template.Annotations = map[string]string{}
+		}
+		updated.Spec.Template.Annotations[policyv1alpha1.PropagationPolicyAnnotation] = key
+	}
+	if err := p.Client.Patch(ctx, updated, client.MergeFrom(deploy)); err != nil {
+		return err
+	}
+	klog.Infof("recorded %s on deployment %s/%s", utils.FormatPolicy("PropagationPolicy", policy), deploy.Namespace, deploy.Name)
+	return nil
+}
+
+// removePolicyAnnotations removes annotations with the prefix of the policy group and
+// returns true if any of them is removed.
+func removePolicyAnnotations(annotations map[string]string) bool {
+	removed := false
+	for key := range annotations {
+		if strings.HasPrefix(key, policyv1alpha1.GroupName+"/") {
+			delete(annotations, key)
+			removed = true
+		}
+	}
+	return removed
 }
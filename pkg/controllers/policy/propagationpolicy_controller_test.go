@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func TestRestoreWorkload(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotation  string
+		restart     bool
+		wantRestart bool
+	}{
+		{
+			name:       "remove the annotation",
+			annotation: "default/policy",
+		},
+		{
+			name:        "restart the deployment",
+			annotation:  "default/policy",
+			restart:     true,
+			wantRestart: true,
+		},
+		{
+			name:    "never restart the deployment restored before",
+			restart: true,
+		},
+		{
+			name:       "never restart the deployment recorded with another policy",
+			annotation: "default/other",
+			restart:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deploy"}}
+			if c.annotation != "" {
+				deploy.Annotations = map[string]string{policyv1alpha1.PropagationPolicyAnnotation: c.annotation}
+			}
+			policy := &policyv1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"}}
+			policy.Spec.RestartWorkloadsOnDeletion = c.restart
+
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			p := &Controller{
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(deploy).Build(),
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if err := p.restoreWorkload(context.TODO(), policy, deploy); err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+
+			got := &appsv1.Deployment{}
+			if err := p.Client.Get(context.TODO(), client.ObjectKeyFromObject(deploy), got); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := got.Annotations[policyv1alpha1.PropagationPolicyAnnotation]; ok {
+				t.Errorf("want the policy annotation removed, got %v", got.Annotations)
+			}
+			if _, restarted := got.Spec.Template.Annotations[restartedAtAnnotation]; restarted != c.wantRestart {
+				t.Errorf("want restarted %v, got %v", c.wantRestart, restarted)
+			}
+		})
+	}
+}
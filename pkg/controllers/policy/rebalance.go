@@ -0,0 +1,278 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+
+	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
+)
+
+const (
+	// rebalanceRetryPeriod is the amount of time to wait before rebalancing a workload again
+	// when replacements of evicted pods are not ready or evictions are blocked.
+	rebalanceRetryPeriod = 10 * time.Second
+)
+
+// rebalanceWorkload moves surplus pods of the deployment out of their nodegroups with the rebalance
+// strategy of the policy. It returns true if some surplus pods are left to be moved later.
+func (p *Controller) rebalanceWorkload(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploy *appsv1.Deployment,
+	pods []corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string, groups []nodegroupv1alpha1.NodeGroup) (bool, error) {
+	includeStrays := !policy.Spec.Rebalance.KeepStrayPods
+	var surplusPods []corev1.Pod
+	if policy.Spec.Rebalance.Type == policyv1alpha1.DeleteRebalanceStrategy {
+		surplusPods = getPodsNeedToDelete(pods, desiredPods, nodesInNodeGroups, includeStrays)
+	} else {
+		surplusPods = getSurplusPods(pods, desiredPods, nodesInNodeGroups, includeStrays)
+	}
+	if len(surplusPods) == 0 {
+		return false, nil
+	}
+
+	pending := false
+	if policy.Spec.Spillover.Enabled {
+		// pods have spilled over, only move them back when nodegroups which
+		// need more pods have capacity for them, otherwise they spill over again.
+		limit := getAbsorbablePodsNum(deploy, pods, desiredPods, nodesInNodeGroups, groups)
+		if len(surplusPods) > limit {
+			klog.V(2).Infof("nodegroups can only absorb %d of %d surplus pods of deploy %s/%s",
+				limit, len(surplusPods), deploy.Namespace, deploy.Name)
+			surplusPods = surplusPods[:limit]
+			pending = true
+		}
+	}
+
+	if policy.Spec.Rebalance.Type == policyv1alpha1.DeleteRebalanceStrategy {
+		return pending, p.deletePods(ctx, policy, deploy, surplusPods, desiredPods, nodesInNodeGroups)
+	}
+
+	// evicting a pod which is not ready does not reduce the available pods,
+	// so only ready pods consume the budget.
+	maxUnavailable := getMaxUnavailable(policy, *deploy.Spec.Replicas)
+	budget := maxUnavailable - getUnavailablePodsNum(pods, *deploy.Spec.Replicas)
+	errs := []error{}
+	for i := range surplusPods {
+		pod := &surplusPods[i]
+		ready := isPodReady(pod)
+		if ready && budget <= 0 {
+			if maxUnavailable == 0 {
+				// ready pods are never evicted, waiting does not help
+				klog.V(2).Infof("no pod of deploy %s/%s can be unavailable, keep ready surplus pods", deploy.Namespace, deploy.Name)
+				break
+			}
+			klog.V(2).Infof("wait for pods of deploy %s/%s to become ready before evicting pod %s/%s",
+				deploy.Namespace, deploy.Name, pod.Namespace, pod.Name)
+			pending = true
+			break
+		}
+
+		blocked, err := p.evictPod(ctx, policy, deploy, pod, describeSurplusPod(pod, desiredPods, nodesInNodeGroups))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if blocked {
+			pending = true
+			continue
+		}
+		if ready {
+			budget--
+		}
+	}
+	return pending, errors.NewAggregate(errs)
+}
+
+// evictPod evicts the pod through the Eviction API. It returns true if the eviction is
+// blocked by PodDisruptionBudgets. The description of the pod is used in events.
+func (p *Controller) evictPod(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploy *appsv1.Deployment, pod *corev1.Pod, description string) (bool, error) {
+	klog.Infof("evicting pod %s/%s", pod.Namespace, pod.Name)
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+	err := p.KubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+	switch {
+	case err == nil:
+		p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeNormal, events.EventReasonRebalancePod,
+			"Evicted pod %s/%s on node %s %s", pod.Namespace, pod.Name, pod.Spec.NodeName, description)
+		return false, nil
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case apierrors.IsTooManyRequests(err):
+		klog.V(2).Infof("eviction of pod %s/%s is blocked by PodDisruptionBudget, %v", pod.Namespace, pod.Name, err)
+		p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeWarning, events.EventReasonRebalancePodFailed,
+			"Eviction of pod %s/%s on node %s for rebalancing is blocked, %v", pod.Namespace, pod.Name, pod.Spec.NodeName, err)
+		return true, nil
+	default:
+		klog.Errorf("failed to evict pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeWarning, events.EventReasonRebalancePodFailed,
+			"Failed to evict pod %s/%s on node %s for rebalancing, %v", pod.Namespace, pod.Name, pod.Spec.NodeName, err)
+		return false, err
+	}
+}
+
+// deletePods deletes the pods immediately.
+func (p *Controller) deletePods(ctx context.Context, policy *policyv1alpha1.PropagationPolicy, deploy *appsv1.Deployment, pods []corev1.Pod,
+	desiredPods map[string]int32, nodesInNodeGroups map[string]string) error {
+	errs := []error{}
+	for _, pod := range pods {
+		klog.Infof("deleting pod %s/%s", pod.Namespace, pod.Name)
+		if err := p.Client.Delete(ctx, &pod); err != nil && !apierrors.IsNotFound(err) {
+			klog.Errorf("failed to delete pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeWarning, events.EventReasonRebalancePodFailed,
+				"Failed to delete pod %s/%s on node %s for rebalancing, %v", pod.Namespace, pod.Name, pod.Spec.NodeName, err)
+			errs = append(errs, err)
+			continue
+		}
+		p.recordEvent(policy, []*appsv1.Deployment{deploy}, corev1.EventTypeNormal, events.EventReasonRebalancePod,
+			"Deleted pod %s/%s on node %s %s", pod.Namespace, pod.Name, pod.Spec.NodeName, describeSurplusPod(&pod, desiredPods, nodesInNodeGroups))
+	}
+	return errors.NewAggregate(errs)
+}
+
+// getMaxUnavailable returns the maximum number of unavailable pods of a workload with the replicas.
+// Percentages are rounded down, and 0 means ready pods are never evicted. It is at most replicas-1,
+// so that the only or last ready pod of a workload is never evicted before a replacement is ready.
+func getMaxUnavailable(policy *policyv1alpha1.PropagationPolicy, replicas int32) int32 {
+	maxUnavailable := policy.Spec.Rebalance.MaxUnavailable
+	if maxUnavailable == nil {
+		maxUnavailable = &policyv1alpha1.DefaultMaxUnavailable
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, int(replicas), false)
+	if err != nil {
+		klog.Errorf("invalid maxUnavailable %s of policy %s/%s, use default value, %v",
+			maxUnavailable.String(), policy.Namespace, policy.Name, err)
+		value = policyv1alpha1.DefaultMaxUnavailable.IntValue()
+	}
+	if value > int(replicas)-1 {
+		value = int(replicas) - 1
+	}
+	if value < 0 {
+		value = 0
+	}
+	return int32(value)
+}
+
+// getUnavailablePodsNum returns the number of desired replicas which do not have a ready pod.
+// Pods being deleted are not considered ready.
+func getUnavailablePodsNum(pods []corev1.Pod, replicas int32) int32 {
+	var readyPods int32
+	for i := range pods {
+		if pods[i].DeletionTimestamp == nil && isPodReady(&pods[i]) {
+			readyPods++
+		}
+	}
+	if readyPods >= replicas {
+		return 0
+	}
+	return replicas - readyPods
+}
+
+// getSurplusPods returns pods in nodegroups that have more pods than desired, along with stray pods
+// if required, ordered by the preference to be moved: pods which are not ready first, and then the
+// newest ones.
+func getSurplusPods(pods []corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string, includeStrays bool) []corev1.Pod {
+	podsInGroups := make(map[string][]corev1.Pod)
+	strayPods := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if isStrayPod(&pod, desiredPods, nodesInNodeGroups) {
+			strayPods = append(strayPods, pod)
+			continue
+		}
+		groupname := nodesInNodeGroups[pod.Spec.NodeName]
+		podsInGroups[groupname] = append(podsInGroups[groupname], pod)
+	}
+
+	surplusPods := []corev1.Pod{}
+	for groupname, groupPods := range podsInGroups {
+		surplus := len(groupPods) - int(desiredPods[groupname])
+		if surplus <= 0 {
+			continue
+		}
+		sortPodsByEvictionPreference(groupPods)
+		klog.V(2).Infof("nodegroup %s has %d more pods than desired", groupname, surplus)
+		surplusPods = append(surplusPods, groupPods[:surplus]...)
+	}
+	if includeStrays && len(strayPods) != 0 {
+		klog.V(2).Infof("find %d pods running outside target nodegroups", len(strayPods))
+		surplusPods = append(surplusPods, strayPods...)
+	}
+	sortPodsByEvictionPreference(surplusPods)
+	return surplusPods
+}
+
+// sortPodsByEvictionPreference sorts pods which are not ready before ready ones, and newer
+// pods before older ones.
+func sortPodsByEvictionPreference(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		readyI, readyJ := isPodReady(&pods[i]), isPodReady(&pods[j])
+		if readyI != readyJ {
+			return !readyI
+		}
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
+
+func getPodsNeedToDelete(pods []corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string, includeStrays bool) []corev1.Pod {
+	deletePod := []corev1.Pod{}
+	count := make(map[string]int32)
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if isStrayPod(&pod, desiredPods, nodesInNodeGroups) {
+			if includeStrays {
+				klog.V(2).Infof("pod %s/%s is running outside target nodegroups, add it to delete queue", pod.Namespace, pod.Name)
+				deletePod = append(deletePod, pod)
+			}
+			continue
+		}
+		if groupname, ok := nodesInNodeGroups[pod.Spec.NodeName]; ok {
+			count[groupname]++
+			if count[groupname] > desiredPods[groupname] {
+				// More than desired number of pods can run in this nodegroup
+				klog.V(2).Infof("pod %s/%s in nodegroup %s is no longer needed, add it to delete queue", pod.Namespace, pod.Name, groupname)
+				deletePod = append(deletePod, pod)
+			}
+		}
+	}
+	return deletePod
+}
+
+// describeSurplusPod returns why the pod is moved, which is used in events.
+func describeSurplusPod(pod *corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string) string {
+	if isStrayPod(pod, desiredPods, nodesInNodeGroups) {
+		return "outside target nodegroups"
+	}
+	return "whose nodegroup has more pods than desired"
+}
+
+// isStrayPod returns true if the pod is running on a node outside the target nodegroups.
+func isStrayPod(pod *corev1.Pod, desiredPods map[string]int32, nodesInNodeGroups map[string]string) bool {
+	groupname, ok := nodesInNodeGroups[pod.Spec.NodeName]
+	if !ok {
+		return true
+	}
+	_, ok = desiredPods[groupname]
+	return !ok
+}
@@ -0,0 +1,416 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+var testTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestPod(name, node string, ready bool, age time.Duration) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(testTime.Add(-age)),
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestGetMaxUnavailable(t *testing.T) {
+	cases := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		replicas       int32
+		want           int32
+	}{
+		{
+			name:     "default",
+			replicas: 4,
+			want:     1,
+		},
+		{
+			name:           "absolute number",
+			maxUnavailable: intstrPtr(intstr.FromInt(2)),
+			replicas:       4,
+			want:           2,
+		},
+		{
+			name:           "percentage rounded down",
+			maxUnavailable: intstrPtr(intstr.FromString("30%")),
+			replicas:       5,
+			want:           1,
+		},
+		{
+			name:           "percentage rounded down to 0",
+			maxUnavailable: intstrPtr(intstr.FromString("25%")),
+			replicas:       3,
+			want:           0,
+		},
+		{
+			name:           "no disruption",
+			maxUnavailable: intstrPtr(intstr.FromInt(0)),
+			replicas:       4,
+			want:           0,
+		},
+		{
+			name:     "single replica keeps its pod",
+			replicas: 1,
+			want:     0,
+		},
+		{
+			name:           "keep the last ready pod",
+			maxUnavailable: intstrPtr(intstr.FromString("100%")),
+			replicas:       3,
+			want:           2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := &policyv1alpha1.PropagationPolicy{}
+			policy.Spec.Rebalance.MaxUnavailable = c.maxUnavailable
+			if got := getMaxUnavailable(policy, c.replicas); got != c.want {
+				t.Errorf("want %d, got %d", c.want, got)
+			}
+		})
+	}
+}
+
+func intstrPtr(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
+
+func TestGetUnavailablePodsNum(t *testing.T) {
+	deleting := newTestPod("deleting", "node1", true, time.Hour)
+	deleting.DeletionTimestamp = &metav1.Time{Time: testTime}
+
+	cases := []struct {
+		name     string
+		pods     []corev1.Pod
+		replicas int32
+		want     int32
+	}{
+		{
+			name:     "all ready",
+			pods:     []corev1.Pod{newTestPod("a", "node1", true, time.Hour), newTestPod("b", "node1", true, time.Hour)},
+			replicas: 2,
+			want:     0,
+		},
+		{
+			name:     "not ready and deleting pods are unavailable",
+			pods:     []corev1.Pod{newTestPod("a", "node1", true, time.Hour), newTestPod("b", "node1", false, time.Hour), deleting},
+			replicas: 3,
+			want:     2,
+		},
+		{
+			name:     "more ready pods than replicas",
+			pods:     []corev1.Pod{newTestPod("a", "node1", true, time.Hour), newTestPod("b", "node1", true, time.Hour)},
+			replicas: 1,
+			want:     0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := getUnavailablePodsNum(c.pods, c.replicas); got != c.want {
+				t.Errorf("want %d, got %d", c.want, got)
+			}
+		})
+	}
+}
+
+func TestSortPodsByEvictionPreference(t *testing.T) {
+	pods := []corev1.Pod{
+		newTestPod("old-ready", "node1", true, 2*time.Hour),
+		newTestPod("new-ready", "node1", true, time.Hour),
+		newTestPod("old-not-ready", "node1", false, 2*time.Hour),
+		newTestPod("b-same-age", "node1", true, time.Hour),
+		newTestPod("new-not-ready", "node1", false, time.Hour),
+	}
+	sortPodsByEvictionPreference(pods)
+
+	want := []string{"new-not-ready", "old-not-ready", "b-same-age", "new-ready", "old-ready"}
+	if got := podNames(pods); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestGetSurplusPods(t *testing.T) {
+	nodesInNodeGroups := map[string]string{
+		"node1": "beijing",
+		"node2": "hangzhou",
+		"node3": "shanghai",
+	}
+	deleting := newTestPod("deleting", "node1", true, time.Hour)
+	deleting.DeletionTimestamp = &metav1.Time{Time: testTime}
+	pods := []corev1.Pod{
+		newTestPod("beijing-old", "node1", true, 3*time.Hour),
+		newTestPod("beijing-new", "node1", true, time.Hour),
+		newTestPod("beijing-not-ready", "node1", false, 2*time.Hour),
+		deleting,
+		newTestPod("hangzhou", "node2", true, time.Hour),
+		newTestPod("pending", "", false, time.Hour),
+		// shanghai is not a target nodegroup
+		newTestPod("stray", "node3", true, 2*time.Hour),
+		newTestPod("outside", "node4", true, 4*time.Hour),
+	}
+	desiredPods := map[string]int32{
+		"beijing":  1,
+		"hangzhou": 2,
+	}
+
+	cases := []struct {
+		name          string
+		includeStrays bool
+		want          []string
+	}{
+		{
+			name: "surplus pods",
+			want: []string{"beijing-not-ready", "beijing-new"},
+		},
+		{
+			name:          "surplus pods and stray pods",
+			includeStrays: true,
+			want:          []string{"beijing-not-ready", "beijing-new", "stray", "outside"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := podNames(getSurplusPods(pods, desiredPods, nodesInNodeGroups, c.includeStrays))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestGetPodsNeedToDelete(t *testing.T) {
+	nodesInNodeGroups := map[string]string{
+		"node1": "beijing",
+		"node2": "hangzhou",
+		"node3": "shanghai",
+	}
+	pods := []corev1.Pod{
+		newTestPod("beijing-1", "node1", true, 3*time.Hour),
+		newTestPod("beijing-2", "node1", true, time.Hour),
+		newTestPod("hangzhou", "node2", true, time.Hour),
+		newTestPod("pending", "", false, time.Hour),
+		// shanghai is not a target nodegroup
+		newTestPod("stray", "node3", true, 2*time.Hour),
+		newTestPod("outside", "node4", true, 4*time.Hour),
+	}
+	desiredPods := map[string]int32{
+		"beijing":  1,
+		"hangzhou": 2,
+	}
+
+	cases := []struct {
+		name          string
+		includeStrays bool
+		want          []string
+	}{
+		{
+			name: "surplus pods",
+			want: []string{"beijing-2"},
+		},
+		{
+			name:          "surplus pods and stray pods",
+			includeStrays: true,
+			want:          []string{"beijing-2", "stray", "outside"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := podNames(getPodsNeedToDelete(pods, desiredPods, nodesInNodeGroups, c.includeStrays))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestRebalanceWorkloadEviction(t *testing.T) {
+	nodesInNodeGroups := map[string]string{
+		"node1": "beijing",
+		"node2": "hangzhou",
+	}
+	desiredPods := map[string]int32{
+		"beijing":  1,
+		"hangzhou": 3,
+	}
+	// beijing-new and beijing-mid are surplus pods, beijing-new is moved first
+	readyPods := []corev1.Pod{
+		newTestPod("beijing-old", "node1", true, 3*time.Hour),
+		newTestPod("beijing-mid", "node1", true, 2*time.Hour),
+		newTestPod("beijing-new", "node1", true, time.Hour),
+		newTestPod("hangzhou", "node2", true, time.Hour),
+	}
+	// beijing-not-ready is moved before beijing-mid
+	notReadyPods := []corev1.Pod{
+		newTestPod("beijing-old", "node1", true, 3*time.Hour),
+		newTestPod("beijing-mid", "node1", true, 2*time.Hour),
+		newTestPod("beijing-not-ready", "node1", false, time.Hour),
+		newTestPod("hangzhou", "node2", true, time.Hour),
+	}
+	// beijing-new is moved before the older stray pod
+	strayPods := []corev1.Pod{
+		newTestPod("beijing-old", "node1", true, 3*time.Hour),
+		newTestPod("beijing-new", "node1", true, time.Hour),
+		newTestPod("stray", "node3", true, 2*time.Hour),
+		newTestPod("hangzhou", "node2", true, time.Hour),
+	}
+	podResource := schema.GroupResource{Resource: "pods"}
+
+	cases := []struct {
+		name           string
+		pods           []corev1.Pod
+		maxUnavailable int
+		evictErrs      map[string]error
+		wantEvicted    []string
+		wantEvents     []string
+		wantPending    bool
+		wantErr        bool
+	}{
+		{
+			name:           "wait for pods to become ready",
+			pods:           readyPods,
+			maxUnavailable: 1,
+			wantEvicted:    []string{"beijing-new"},
+			wantPending:    true,
+		},
+		{
+			name:           "pods which are not ready do not consume the budget",
+			pods:           notReadyPods,
+			maxUnavailable: 2,
+			wantEvicted:    []string{"beijing-not-ready", "beijing-mid"},
+		},
+		{
+			name:           "ready pods are kept if no pod can be unavailable",
+			pods:           notReadyPods,
+			maxUnavailable: 0,
+			wantEvicted:    []string{"beijing-not-ready"},
+		},
+		{
+			name:           "stray pods",
+			pods:           strayPods,
+			maxUnavailable: 2,
+			wantEvicted:    []string{"beijing-new", "stray"},
+			wantEvents: []string{
+				"Normal RebalancePod Evicted pod default/beijing-new on node node1 whose nodegroup has more pods than desired",
+				"Normal RebalancePod Evicted pod default/stray on node node3 outside target nodegroups",
+			},
+		},
+		{
+			name:           "eviction blocked by PodDisruptionBudget",
+			pods:           readyPods,
+			maxUnavailable: 2,
+			evictErrs:      map[string]error{"beijing-new": apierrors.NewTooManyRequests("blocked", 10)},
+			wantEvicted:    []string{"beijing-mid"},
+			wantPending:    true,
+		},
+		{
+			name:           "pod already deleted",
+			pods:           readyPods,
+			maxUnavailable: 2,
+			evictErrs:      map[string]error{"beijing-new": apierrors.NewNotFound(podResource, "beijing-new")},
+			wantEvicted:    []string{"beijing-mid"},
+		},
+		{
+			name:           "eviction failed",
+			pods:           readyPods,
+			maxUnavailable: 2,
+			evictErrs:      map[string]error{"beijing-new": apierrors.NewInternalError(fmt.Errorf("failed"))},
+			wantEvicted:    []string{"beijing-mid"},
+			wantErr:        true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evicted := []string{}
+			kubeClient := kubefake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(clienttesting.CreateAction).GetObject().(*policyv1.Eviction)
+				if err, ok := c.evictErrs[eviction.Name]; ok {
+					return true, nil, err
+				}
+				evicted = append(evicted, eviction.Name)
+				return true, nil, nil
+			})
+
+			replicas := int32(4)
+			deploy := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deploy"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			}
+			policy := &policyv1alpha1.PropagationPolicy{}
+			policy.Spec.Rebalance.Type = policyv1alpha1.EvictRebalanceStrategy
+			policy.Spec.Rebalance.MaxUnavailable = intstrPtr(intstr.FromInt(c.maxUnavailable))
+
+			recorder := record.NewFakeRecorder(10)
+			p := &Controller{KubeClient: kubeClient, EventRecorder: recorder}
+			pending, err := p.rebalanceWorkload(context.TODO(), policy, deploy, c.pods, desiredPods, nodesInNodeGroups, nil)
+			if (err != nil) != c.wantErr {
+				t.Errorf("want error %v, got %v", c.wantErr, err)
+			}
+			if pending != c.wantPending {
+				t.Errorf("want pending %v, got %v", c.wantPending, pending)
+			}
+			if !reflect.DeepEqual(evicted, c.wantEvicted) {
+				t.Errorf("want evicted pods %v, got %v", c.wantEvicted, evicted)
+			}
+			for _, want := range c.wantEvents {
+				// events are recorded for the policy and the deployment
+				for i := 0; i < 2; i++ {
+					select {
+					case got := <-recorder.Events:
+						if got != want {
+							t.Errorf("want event %q, got %q", want, got)
+						}
+					default:
+						t.Errorf("want event %q, got none", want)
+					}
+				}
+			}
+		})
+	}
+}
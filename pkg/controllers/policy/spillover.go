@@ -0,0 +1,88 @@
+package policy
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+// getAbsorbablePodsNum returns the number of pods of the deployment that nodegroups which need more
+// pods can take in, according to their unrequested allocatable resources. Pods of the deployment that
+// have not been scheduled are assumed to take in first.
+func getAbsorbablePodsNum(deploy *appsv1.Deployment, pods []corev1.Pod, desiredPods map[string]int32,
+	nodesInNodeGroups map[string]string, groups []nodegroupv1alpha1.NodeGroup) int {
+	groupMap := make(map[string]*nodegroupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+
+	currentPods := make(map[string]int32)
+	unscheduledPods := 0
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Spec.NodeName == "" {
+			unscheduledPods++
+			continue
+		}
+		if groupname, ok := nodesInNodeGroups[pod.Spec.NodeName]; ok {
+			currentPods[groupname]++
+		}
+	}
+
+	requests := utils.GetPodRequests(&corev1.Pod{Spec: deploy.Spec.Template.Spec})
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	absorbable := 0
+	for groupname, desired := range desiredPods {
+		lacking := int(desired - currentPods[groupname])
+		group, ok := groupMap[groupname]
+		if lacking <= 0 || !ok {
+			continue
+		}
+		if fits := getFittingPodsNum(group, requests); fits < lacking {
+			lacking = fits
+		}
+		absorbable += lacking
+	}
+
+	absorbable -= unscheduledPods
+	if absorbable < 0 {
+		return 0
+	}
+	return absorbable
+}
+
+// getFittingPodsNum returns how many pods with the requests fit in the unrequested allocatable
+// resources of the nodegroup. Fragmentation across nodes is not taken into account.
+func getFittingPodsNum(group *nodegroupv1alpha1.NodeGroup, requests corev1.ResourceList) int {
+	fits := -1
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+		allocatable, ok := group.Status.Allocatable[name]
+		if !ok {
+			continue
+		}
+		free := allocatable.DeepCopy()
+		if requested, ok := group.Status.Requested[name]; ok {
+			free.Sub(requested)
+		}
+		num := 0
+		if free.Sign() > 0 {
+			num = int(free.MilliValue() / request.MilliValue())
+		}
+		if fits < 0 || num < fits {
+			fits = num
+		}
+	}
+	if fits < 0 {
+		return 0
+	}
+	return fits
+}
@@ -0,0 +1,152 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodegroupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+)
+
+func newTestNodeGroup(name, allocatableCPU, requestedCPU string) nodegroupv1alpha1.NodeGroup {
+	group := nodegroupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	group.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(allocatableCPU),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	group.Status.Requested = corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse(requestedCPU),
+	}
+	return group
+}
+
+func TestGetFittingPodsNum(t *testing.T) {
+	cases := []struct {
+		name     string
+		group    nodegroupv1alpha1.NodeGroup
+		requests corev1.ResourceList
+		want     int
+	}{
+		{
+			name:  "limited by cpu",
+			group: newTestNodeGroup("beijing", "4", "1500m"),
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			want: 5,
+		},
+		{
+			name:  "limited by memory",
+			group: newTestNodeGroup("beijing", "4", "0"),
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+			want: 2,
+		},
+		{
+			name:  "fully requested",
+			group: newTestNodeGroup("beijing", "4", "5"),
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			},
+			want: 0,
+		},
+		{
+			name:  "zero and unknown requests are ignored",
+			group: newTestNodeGroup("beijing", "4", "0"),
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("0"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				corev1.ResourcePods:             resource.MustParse("1"),
+			},
+			want: 110,
+		},
+		{
+			name:     "no requests",
+			group:    newTestNodeGroup("beijing", "4", "0"),
+			requests: corev1.ResourceList{},
+			want:     0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := getFittingPodsNum(&c.group, c.requests); got != c.want {
+				t.Errorf("want %d, got %d", c.want, got)
+			}
+		})
+	}
+}
+
+func TestGetAbsorbablePodsNum(t *testing.T) {
+	deploy := &appsv1.Deployment{}
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name: "app",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}}
+	nodesInNodeGroups := map[string]string{
+		"node1": "beijing",
+		"node2": "hangzhou",
+	}
+	groups := []nodegroupv1alpha1.NodeGroup{
+		newTestNodeGroup("beijing", "4", "2"),
+		newTestNodeGroup("hangzhou", "4", "0"),
+	}
+	deleting := newTestPod("deleting", "node1", true, time.Hour)
+	deleting.DeletionTimestamp = &metav1.Time{Time: testTime}
+
+	cases := []struct {
+		name        string
+		pods        []corev1.Pod
+		desiredPods map[string]int32
+		want        int
+	}{
+		{
+			name:        "lacking pods of each nodegroup",
+			pods:        []corev1.Pod{newTestPod("a", "node1", true, time.Hour), deleting},
+			desiredPods: map[string]int32{"beijing": 2, "hangzhou": 1},
+			want:        2,
+		},
+		{
+			name:        "limited by free resources",
+			pods:        []corev1.Pod{},
+			desiredPods: map[string]int32{"beijing": 3, "hangzhou": 1},
+			want:        3,
+		},
+		{
+			name:        "unscheduled pods take in first",
+			pods:        []corev1.Pod{newTestPod("a", "", false, time.Hour)},
+			desiredPods: map[string]int32{"beijing": 1, "hangzhou": 1},
+			want:        1,
+		},
+		{
+			name:        "nodegroups have enough pods",
+			pods:        []corev1.Pod{newTestPod("a", "node1", true, time.Hour), newTestPod("b", "", false, time.Hour)},
+			desiredPods: map[string]int32{"beijing": 1},
+			want:        0,
+		},
+		{
+			name:        "unknown nodegroups are skipped",
+			pods:        []corev1.Pod{},
+			desiredPods: map[string]int32{"shanghai": 2},
+			want:        0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := getAbsorbablePodsNum(deploy, c.pods, c.desiredPods, nodesInNodeGroups, groups); got != c.want {
+				t.Errorf("want %d, got %d", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package events
+
+// Define event reasons.
+const (
+	// EventReasonRebalancePod indicates that a pod is deleted to rebalance pods across nodegroups.
+	EventReasonRebalancePod = "RebalancePod"
+	// EventReasonRebalancePodFailed indicates that a pod failed to be deleted for rebalancing.
+	EventReasonRebalancePodFailed = "RebalancePodFailed"
+	// EventReasonNodeGroupNotFound indicates that a nodegroup referenced by a policy does not exist.
+	EventReasonNodeGroupNotFound = "NodeGroupNotFound"
+	// EventReasonNodeGroupEmpty indicates that a nodegroup contains no node.
+	EventReasonNodeGroupEmpty = "NodeGroupEmpty"
+	// EventReasonNodeGroupDeletionBlocked indicates that a nodegroup cannot be deleted because it is still referenced by policies.
+	EventReasonNodeGroupDeletionBlocked = "NodeGroupDeletionBlocked"
+	// EventReasonRestoreWorkload indicates that a workload is restored to default scheduling after its policy is deleted.
+	EventReasonRestoreWorkload = "RestoreWorkload"
+	// EventReasonRestoreWorkloadFailed indicates that a workload failed to be restored to default scheduling.
+	EventReasonRestoreWorkloadFailed = "RestoreWorkloadFailed"
+	// EventReasonNodeGroupFailedOver indicates that pods of an offline nodegroup are redistributed to other nodegroups.
+	EventReasonNodeGroupFailedOver = "NodeGroupFailedOver"
+	// EventReasonNodeGroupRecovered indicates that pods are placed in a failed over nodegroup again.
+	EventReasonNodeGroupRecovered = "NodeGroupRecovered"
+	// EventReasonSpillover indicates that a pod is placed outside the nodegroups which need more pods because they lack capacity.
+	EventReasonSpillover = "Spillover"
+	// EventReasonNoAvailableNodes indicates that the scheduler extender filtered out all nodes for a pod.
+	EventReasonNoAvailableNodes = "NoAvailableNodes"
+	// EventReasonOverrideConflict indicates that overriders of a policy are overridden by policies with higher precedence.
+	EventReasonOverrideConflict = "OverrideConflict"
+)
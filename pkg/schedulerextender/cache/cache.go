@@ -1,9 +1,239 @@
 package cache
 
-// TODO:
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/generated/clientset/versioned"
+	"github.com/Congrool/nodes-grouping/pkg/generated/informers/externalversions"
+	grouplisters "github.com/Congrool/nodes-grouping/pkg/generated/listers/group/v1alpha1"
+	policylisters "github.com/Congrool/nodes-grouping/pkg/generated/listers/policy/v1alpha1"
+)
+
+// ExtenderCache caches objects read by the scheduler extender with shared informers,
+// so that scheduling requests are served without requests to the apiserver.
 type ExtenderCache interface {
-	// TODO: client interface to get obj
-	// GetCacheReadClient()  CacheGetter
+	// Reader returns the reader of cached objects. Objects of kinds which are not cached,
+	// and lists with field selectors, are read from the apiserver.
+	Reader() client.Reader
+	// Start starts informers of the cache and blocks until they are synced.
+	Start(stopCh <-chan struct{}) error
+}
+
+type extenderCache struct {
+	// apiReader reads objects which are not cached from the apiserver.
+	apiReader client.Reader
+
+	kubeInformerFactory     kubeinformers.SharedInformerFactory
+	groupingInformerFactory externalversions.SharedInformerFactory
+	informersSynced         []cache.InformerSynced
+
+	nodeLister                     corelisters.NodeLister
+	podLister                      corelisters.PodLister
+	deploymentLister               appslisters.DeploymentLister
+	replicaSetLister               appslisters.ReplicaSetLister
+	nodeGroupLister                grouplisters.NodeGroupLister
+	propagationPolicyLister        policylisters.PropagationPolicyLister
+	clusterPropagationPolicyLister policylisters.ClusterPropagationPolicyLister
+}
+
+var _ client.Reader = &extenderCache{}
+
+// New creates an ExtenderCache of Nodes, Pods, Deployments, ReplicaSets, NodeGroups,
+// PropagationPolicies and ClusterPropagationPolicies.
+func New(kubeClient kubernetes.Interface, groupingClient versioned.Interface, apiReader client.Reader) ExtenderCache {
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	groupingInformerFactory := externalversions.NewSharedInformerFactory(groupingClient, 0)
+
+	nodeInformer := kubeInformerFactory.Core().V1().Nodes()
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	deploymentInformer := kubeInformerFactory.Apps().V1().Deployments()
+	replicaSetInformer := kubeInformerFactory.Apps().V1().ReplicaSets()
+	nodeGroupInformer := groupingInformerFactory.Group().V1alpha1().NodeGroups()
+	propagationPolicyInformer := groupingInformerFactory.Policy().V1alpha1().PropagationPolicies()
+	clusterPropagationPolicyInformer := groupingInformerFactory.Policy().V1alpha1().ClusterPropagationPolicies()
+
+	return &extenderCache{
+		apiReader:               apiReader,
+		kubeInformerFactory:     kubeInformerFactory,
+		groupingInformerFactory: groupingInformerFactory,
+		informersSynced: []cache.InformerSynced{
+			nodeInformer.Informer().HasSynced,
+			podInformer.Informer().HasSynced,
+			deploymentInformer.Informer().HasSynced,
+			replicaSetInformer.Informer().HasSynced,
+			nodeGroupInformer.Informer().HasSynced,
+			propagationPolicyInformer.Informer().HasSynced,
+			clusterPropagationPolicyInformer.Informer().HasSynced,
+		},
+		nodeLister:                     nodeInformer.Lister(),
+		podLister:                      podInformer.Lister(),
+		deploymentLister:               deploymentInformer.Lister(),
+		replicaSetLister:               replicaSetInformer.Lister(),
+		nodeGroupLister:                nodeGroupInformer.Lister(),
+		propagationPolicyLister:        propagationPolicyInformer.Lister(),
+		clusterPropagationPolicyLister: clusterPropagationPolicyInformer.Lister(),
+	}
+}
+
+func (c *extenderCache) Reader() client.Reader {
+	return c
+}
+
+func (c *extenderCache) Start(stopCh <-chan struct{}) error {
+	klog.Info("starting scheduler extender cache")
+	c.kubeInformerFactory.Start(stopCh)
+	c.groupingInformerFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.informersSynced...) {
+		return fmt.Errorf("failed to wait for scheduler extender cache to sync")
+	}
+	return nil
+}
+
+// Get retrieves the object from listers. Objects returned by listers are shared
+// with the cache, so they are deep copied.
+func (c *extenderCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	switch o := obj.(type) {
+	case *corev1.Node:
+		node, err := c.nodeLister.Get(key.Name)
+		if err != nil {
+			return err
+		}
+		node.DeepCopyInto(o)
+	case *corev1.Pod:
+		pod, err := c.podLister.Pods(key.Namespace).Get(key.Name)
+		if err != nil {
+			return err
+		}
+		pod.DeepCopyInto(o)
+	case *appsv1.Deployment:
+		deploy, err := c.deploymentLister.Deployments(key.Namespace).Get(key.Name)
+		if err != nil {
+			return err
+		}
+		deploy.DeepCopyInto(o)
+	case *appsv1.ReplicaSet:
+		rs, err := c.replicaSetLister.ReplicaSets(key.Namespace).Get(key.Name)
+		if err != nil {
+			return err
+		}
+		rs.DeepCopyInto(o)
+	case *groupv1alpha1.NodeGroup:
+		group, err := c.nodeGroupLister.Get(key.Name)
+		if err != nil {
+			return err
+		}
+		group.DeepCopyInto(o)
+	case *policyv1alpha1.PropagationPolicy:
+		policy, err := c.propagationPolicyLister.PropagationPolicies(key.Namespace).Get(key.Name)
+		if err != nil {
+			return err
+		}
+		policy.DeepCopyInto(o)
+	case *policyv1alpha1.ClusterPropagationPolicy:
+		policy, err := c.clusterPropagationPolicyLister.Get(key.Name)
+		if err != nil {
+			return err
+		}
+		policy.DeepCopyInto(o)
+	default:
+		return c.apiReader.Get(ctx, key, obj)
+	}
+	return nil
+}
+
+// List retrieves the list of objects matching the namespace and label selector from listers.
+func (c *extenderCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		// listers cannot select objects by fields
+		return c.apiReader.List(ctx, list, opts...)
+	}
+	selector := listOpts.LabelSelector
+	if selector == nil {
+		selector = labels.Everything()
+	}
 
-	// TODO: interface to register handler
+	switch l := list.(type) {
+	case *corev1.NodeList:
+		nodes, err := c.nodeLister.List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]corev1.Node, 0, len(nodes))
+		for _, node := range nodes {
+			l.Items = append(l.Items, *node.DeepCopy())
+		}
+	case *corev1.PodList:
+		pods, err := c.podLister.Pods(listOpts.Namespace).List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]corev1.Pod, 0, len(pods))
+		for _, pod := range pods {
+			l.Items = append(l.Items, *pod.DeepCopy())
+		}
+	case *appsv1.DeploymentList:
+		deploys, err := c.deploymentLister.Deployments(listOpts.Namespace).List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]appsv1.Deployment, 0, len(deploys))
+		for _, deploy := range deploys {
+			l.Items = append(l.Items, *deploy.DeepCopy())
+		}
+	case *appsv1.ReplicaSetList:
+		replicaSets, err := c.replicaSetLister.ReplicaSets(listOpts.Namespace).List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]appsv1.ReplicaSet, 0, len(replicaSets))
+		for _, rs := range replicaSets {
+			l.Items = append(l.Items, *rs.DeepCopy())
+		}
+	case *groupv1alpha1.NodeGroupList:
+		groups, err := c.nodeGroupLister.List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]groupv1alpha1.NodeGroup, 0, len(groups))
+		for _, group := range groups {
+			l.Items = append(l.Items, *group.DeepCopy())
+		}
+	case *policyv1alpha1.PropagationPolicyList:
+		policies, err := c.propagationPolicyLister.PropagationPolicies(listOpts.Namespace).List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]policyv1alpha1.PropagationPolicy, 0, len(policies))
+		for _, policy := range policies {
+			l.Items = append(l.Items, *policy.DeepCopy())
+		}
+	case *policyv1alpha1.ClusterPropagationPolicyList:
+		policies, err := c.clusterPropagationPolicyLister.List(selector)
+		if err != nil {
+			return err
+		}
+		l.Items = make([]policyv1alpha1.ClusterPropagationPolicy, 0, len(policies))
+		for _, policy := range policies {
+			l.Items = append(l.Items, *policy.DeepCopy())
+		}
+	default:
+		return c.apiReader.List(ctx, list, opts...)
+	}
+	return nil
 }
@@ -1,7 +1,7 @@
 package constants
 
 const (
-	GroupingScheduleKey = "groupingSchedulePolicy"
+	ExtenderName        = "nodegroup-scheduler-extender"
 	ServerListeningAddr = "0.0.0.0"
 	ServerListeningPort = "10053"
 
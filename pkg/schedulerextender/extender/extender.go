@@ -5,6 +5,7 @@ import (
 
 	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/extender/filter"
 	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/extender/prioritizer"
+	"k8s.io/client-go/tools/record"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -30,11 +31,11 @@ func (e *extender) Prioritize(args *extenderv1.ExtenderArgs) (*extenderv1.HostPr
 	return e.prioritizer.Prioritize(args)
 }
 
-func NewSchedulerExtender(ctx context.Context, client client.Client) SchedulerExtender {
+func NewSchedulerExtender(ctx context.Context, client client.Client, recorder record.EventRecorder) SchedulerExtender {
 	return &extender{
 		ctx:         ctx,
 		client:      client,
 		prioritizer: prioritizer.New(ctx, client),
-		filter:      filter.New(ctx, client),
+		filter:      filter.New(ctx, client, recorder),
 	}
 }
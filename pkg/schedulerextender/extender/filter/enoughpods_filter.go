@@ -32,8 +32,16 @@ func (f *enoughPodsFilter) FilterNodes(
 		return nil, fmt.Errorf("failed to get relative deployment of pod %s/%s when filtering nodes for it, %v",
 			pod.Namespace, pod.Name, err)
 	}
+	if relativeDeploy == nil {
+		return nil, fmt.Errorf("pod %s/%s is not controlled by a deployment selected by policy %s/%s",
+			pod.Namespace, pod.Name, policy.Namespace, policy.Name)
+	}
 
-	desiredPodsNumOfEachNodeGroup := utils.DesiredPodsNumInTargetNodeGroups(policy.Spec.Placement.StaticWeightList, *relativeDeploy.Spec.Replicas)
+	desiredPodsNumOfEachNodeGroup, err := utils.GetDesiredPodsNumOfPolicy(ctx, client, policy, *relativeDeploy.Spec.Replicas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get desired number of pods in each target nodegroups for deploy %s/%s, %v",
+			relativeDeploy.Namespace, relativeDeploy.Name, err)
+	}
 	currentPodsNumOfEachNodeGroup, nodesInNodeGroup, err := utils.CurrentPodsNumInTargetNodeGroups(ctx, client, relativeDeploy, policy)
 
 	if err != nil {
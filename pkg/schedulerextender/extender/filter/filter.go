@@ -7,11 +7,15 @@ import (
 	"net/http"
 
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/events"
 	extenderutil "github.com/Congrool/nodes-grouping/pkg/schedulerextender/extender/utils"
 	"github.com/Congrool/nodes-grouping/pkg/utils"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +34,7 @@ type filter struct {
 	ctx           context.Context
 	client        client.Client
 	filterPlugins []FilterPlugin
+	recorder      record.EventRecorder
 	// TODO:
 	// func to get policy
 }
@@ -42,7 +47,7 @@ func (f *filter) Filter(extenderArgs *extenderv1.ExtenderArgs) (*extenderv1.Exte
 	args := extenderArgs.DeepCopy()
 	pod := args.Pod
 
-	_, policy, err := utils.GetRelativeDeployAndPolicy(f.ctx, f.client, pod)
+	deploy, policy, err := utils.GetRelativeDeployAndPolicy(f.ctx, f.client, pod)
 	if err != nil {
 		klog.Errorf("failed to get relative policy for pod %s/%s, %v", pod.Namespace, pod.Name, err)
 		return f.constructFilterResult(args.Nodes.Items), err
@@ -55,6 +60,7 @@ func (f *filter) Filter(extenderArgs *extenderv1.ExtenderArgs) (*extenderv1.Exte
 
 	var nodes []corev1.Node
 	var errs []error
+	var emptiedBy string
 	nodes = append(nodes, args.Nodes.Items...)
 	for _, filterPlugin := range f.filterPlugins {
 		var err error
@@ -71,10 +77,114 @@ func (f *filter) Filter(extenderArgs *extenderv1.ExtenderArgs) (*extenderv1.Exte
 			nodeNames = append(nodeNames, node.Name)
 		}
 		klog.V(2).Infof("after filter plugin: %s, nodes: %v ", filterPlugin.Name(), nodeNames)
+		if len(nodes) == 0 && len(args.Nodes.Items) != 0 {
+			emptiedBy = filterPlugin.Name()
+			break
+		}
+	}
+
+	if emptiedBy != "" && policy.Spec.Spillover.Enabled && deploy != nil {
+		blocked, err := f.lackingNodeGroupsBlocked(deploy, policy, nodes)
+		if err != nil {
+			klog.Errorf("failed to check nodegroups which need more pods for pod %s/%s according to policy %s/%s, %v",
+				pod.Namespace, pod.Name, policy.Namespace, policy.Name, err)
+			errs = append(errs, err)
+		} else if blocked {
+			spilledNodes, err := f.spillover(pod, deploy, policy, args.Nodes.Items)
+			if err != nil {
+				klog.Errorf("failed to spill over pod %s/%s according to policy %s/%s, %v",
+					pod.Namespace, pod.Name, policy.Namespace, policy.Name, err)
+				errs = append(errs, err)
+			}
+			nodes = spilledNodes
+		}
+	}
+	if len(nodes) == 0 && emptiedBy != "" {
+		f.recordNoAvailableNodes(pod, deploy, policy, emptiedBy, len(args.Nodes.Items))
 	}
 	return f.constructFilterResult(nodes), errors.NewAggregate(errs)
 }
 
+// lackingNodeGroupsBlocked returns true if some target nodegroups have fewer pods of the deploy than
+// desired and none of their nodes is in the filtered nodes. Pods surged during rolling updates, when no
+// nodegroup needs more pods, are never spilled over.
+func (f *filter) lackingNodeGroupsBlocked(deploy *appsv1.Deployment, policy *policyv1alpha1.PropagationPolicy, nodes []corev1.Node) (bool, error) {
+	desiredPods, err := utils.GetDesiredPodsNumOfPolicy(f.ctx, f.client, policy, *deploy.Spec.Replicas)
+	if err != nil {
+		return false, err
+	}
+	currentPods, nodesInNodeGroups, err := utils.CurrentPodsNumInTargetNodeGroups(f.ctx, f.client, deploy, policy)
+	if err != nil {
+		return false, err
+	}
+
+	lacking := sets.NewString()
+	for groupname, desired := range desiredPods {
+		if currentPods[groupname] < desired {
+			lacking.Insert(groupname)
+		}
+	}
+	if lacking.Len() == 0 {
+		return false, nil
+	}
+	for _, node := range nodes {
+		if lacking.Has(nodesInNodeGroups[node.Name]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// spillover returns nodes where the pod can be placed when none of the nodegroups which need
+// more pods has a feasible node, which are nodes in the overflow nodegroup if specified, or
+// nodes in other target nodegroups.
+func (f *filter) spillover(pod *corev1.Pod, deploy *appsv1.Deployment, policy *policyv1alpha1.PropagationPolicy, nodes []corev1.Node) ([]corev1.Node, error) {
+	target := "other target nodegroups"
+	var spilledNodes []corev1.Node
+	if overflow := policy.Spec.Spillover.OverflowNodeGroup; overflow != "" {
+		target = fmt.Sprintf("overflow nodegroup %s", overflow)
+		groups, _, err := utils.GetNodeGroupsWithName(f.ctx, f.client, []string{overflow})
+		if err != nil {
+			return nil, err
+		}
+		nodesInGroups, err := utils.GetNodesInGroups(f.ctx, f.client, groups)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			if _, ok := nodesInGroups[node.Name]; ok {
+				spilledNodes = append(spilledNodes, node)
+			}
+		}
+	} else {
+		var err error
+		spilledNodes, err = (&notInNodeGroupsFilter{}).FilterNodes(f.ctx, f.client, pod, nodes, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(spilledNodes) == 0 {
+		return nil, nil
+	}
+
+	messageFmt := "Nodegroups which need more pods have no feasible node for pod %s/%s, spill it over to %s"
+	f.recorder.Eventf(utils.PropagationPolicyObject(policy), corev1.EventTypeNormal, events.EventReasonSpillover, messageFmt, pod.Namespace, pod.Name, target)
+	if deploy != nil {
+		f.recorder.Eventf(deploy, corev1.EventTypeNormal, events.EventReasonSpillover, messageFmt, pod.Namespace, pod.Name, target)
+	}
+	return spilledNodes, nil
+}
+
+// recordNoAvailableNodes records events on the policy and the deploy when all candidate nodes
+// of the pod have been filtered out.
+func (f *filter) recordNoAvailableNodes(pod *corev1.Pod, deploy *appsv1.Deployment, policy *policyv1alpha1.PropagationPolicy, pluginName string, nodesNum int) {
+	messageFmt := "All %d candidate nodes are filtered out for pod %s/%s by plugin %s"
+	f.recorder.Eventf(utils.PropagationPolicyObject(policy), corev1.EventTypeWarning, events.EventReasonNoAvailableNodes, messageFmt, nodesNum, pod.Namespace, pod.Name, pluginName)
+	if deploy != nil {
+		f.recorder.Eventf(deploy, corev1.EventTypeWarning, events.EventReasonNoAvailableNodes, messageFmt, nodesNum, pod.Namespace, pod.Name, pluginName)
+	}
+}
+
 func (f *filter) constructFilterResult(nodes []corev1.Node) *extenderv1.ExtenderFilterResult {
 	if nodes == nil {
 		return &extenderv1.ExtenderFilterResult{}
@@ -94,10 +204,11 @@ func (f *filter) constructFilterResult(nodes []corev1.Node) *extenderv1.Extender
 	return filterResults
 }
 
-func New(ctx context.Context, client client.Client) Filter {
+func New(ctx context.Context, client client.Client, recorder record.EventRecorder) Filter {
 	return &filter{
-		ctx:    ctx,
-		client: client,
+		ctx:      ctx,
+		client:   client,
+		recorder: recorder,
 		filterPlugins: []FilterPlugin{
 			&enoughPodsFilter{},
 			&notInNodeGroupsFilter{},
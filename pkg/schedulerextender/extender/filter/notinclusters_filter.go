@@ -8,6 +8,7 @@ import (
 	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/constants"
 	"github.com/Congrool/nodes-grouping/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,14 +28,15 @@ func (f *notInNodeGroupsFilter) FilterNodes(
 	nodes []corev1.Node,
 	policy *policyv1alpha1.PropagationPolicy) ([]corev1.Node, error) {
 	// get all target nodegroups
-	var nodeGroupNames []string
-	for _, targetWeight := range policy.Spec.Placement.StaticWeightList {
-		nodeGroupNames = append(nodeGroupNames, targetWeight.NodeGroupNames...)
-	}
-	nodegroups, err := utils.GetNodeGroupsWithName(ctx, client, nodeGroupNames)
+	nodegroups, missing, err := utils.GetNodeGroupsWithName(ctx, client, utils.GetTargetNodeGroupNames(policy))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nodegroup obj according to their names, err: %v", err)
 	}
+	if len(missing) != 0 {
+		// the PropagationPolicy controller will report it with events
+		klog.Warningf("nodegroups %v referenced by policy %s/%s do not exist, filter nodes with the other nodegroups",
+			missing, policy.Namespace, policy.Name)
+	}
 
 	// get map that map node to nodegroup it belongs to
 	nodesInGroups, err := utils.GetNodesInGroups(ctx, client, nodegroups)
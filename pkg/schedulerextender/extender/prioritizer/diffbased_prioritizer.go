@@ -42,7 +42,15 @@ func (p *diffBasedPrioritizePlugin) PrioritizeNodes(ctx context.Context, client
 		return nil, fmt.Errorf("failed to get relative deployment for pod %s/%s when prioritizing nodes for it, %v",
 			pod.Namespace, pod.Name, err)
 	}
-	desiredPodsNumOfEachNodeGroup := utils.DesiredPodsNumInTargetNodeGroups(policy.Spec.Placement.StaticWeightList, *relativeDeploy.Spec.Replicas)
+	if relativeDeploy == nil {
+		return nil, fmt.Errorf("pod %s/%s is not controlled by a deployment selected by policy %s/%s",
+			pod.Namespace, pod.Name, policy.Namespace, policy.Name)
+	}
+	desiredPodsNumOfEachNodeGroup, err := utils.GetDesiredPodsNumOfPolicy(ctx, client, policy, *relativeDeploy.Spec.Replicas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get desired pods number in nodegroup for pod %s/%s with policy %s/%s, %v",
+			pod.Namespace, pod.Name, policy.Namespace, policy.Name, err)
+	}
 	currentPodsNumOfEachNodeGroup, nodesInNodeGroup, err := utils.CurrentPodsNumInTargetNodeGroups(ctx, client, relativeDeploy, policy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current pods number in nodegroup for pod %s/%s with policy %s/%s, %v",
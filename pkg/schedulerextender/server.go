@@ -11,6 +11,7 @@ import (
 	"github.com/Congrool/nodes-grouping/pkg/schedulerextender/extender/prioritizer"
 	"github.com/Congrool/nodes-grouping/pkg/utils"
 	"github.com/gorilla/mux"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,14 +26,14 @@ type server struct {
 	ctx        context.Context
 }
 
-func NewPolicyServer(ctx context.Context, client client.Client) Server {
+func NewPolicyServer(ctx context.Context, client client.Client, recorder record.EventRecorder) Server {
 	s := &server{
 		httpserver: &http.Server{
 			Addr: fmt.Sprintf("%s:%s", constants.ServerListeningAddr, constants.ServerListeningPort),
 		},
 		ctx: ctx,
 	}
-	s.scheduler = extender.NewSchedulerExtender(ctx, client)
+	s.scheduler = extender.NewSchedulerExtender(ctx, client, recorder)
 
 	mux := mux.NewRouter()
 	s.registerHandler(mux)
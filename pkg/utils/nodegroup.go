@@ -0,0 +1,312 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+// GetNodeGroupMembership returns the map from node name to names of nodegroups the node belongs to,
+// sorted by name. A node matched by exclusive nodegroups only belongs to the earliest created one of
+// them. Otherwise, it belongs to all nodegroups matching it. In both cases, it also belongs to all
+// ancestors of these nodegroups.
+func GetNodeGroupMembership(nodes []corev1.Node, groups []groupv1alpha1.NodeGroup) (map[string][]string, error) {
+	directMembers := make(map[string]sets.String, len(groups))
+	exclusiveOwners := make(map[string]string)
+	for _, group := range sortNodeGroupsByPrecedence(groups) {
+		members, _, err := MatchNodesInGroup(nodes, group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match nodes for nodegroup %s, %v", group.Name, err)
+		}
+		directMembers[group.Name] = sets.NewString()
+		for _, node := range members {
+			if _, ok := exclusiveOwners[node.Name]; ok {
+				continue
+			}
+			if group.Spec.Exclusive {
+				exclusiveOwners[node.Name] = group.Name
+			}
+			directMembers[group.Name].Insert(node.Name)
+		}
+	}
+
+	groupMap := make(map[string]*groupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+	membership := make(map[string][]string)
+	for _, group := range sortNodeGroupsByName(groups) {
+		for _, node := range expandNodeGroupMembers(group.Name, groupMap, directMembers, sets.NewString()).List() {
+			membership[node] = append(membership[node], group.Name)
+		}
+	}
+	return membership, nil
+}
+
+// expandNodeGroupMembers returns direct members of the nodegroup along with members of all its
+// descendants. Child nodegroups forming a cycle are ignored.
+func expandNodeGroupMembers(name string, groupMap map[string]*groupv1alpha1.NodeGroup, directMembers map[string]sets.String, path sets.String) sets.String {
+	members := sets.NewString(directMembers[name].List()...)
+	group, ok := groupMap[name]
+	if !ok {
+		return members
+	}
+
+	path.Insert(name)
+	defer path.Delete(name)
+	for _, child := range group.Spec.ChildGroups {
+		if path.Has(child.Name) {
+			klog.Warningf("child nodegroup %s of nodegroup %s forms a cycle, ignore it", child.Name, name)
+			continue
+		}
+		members = members.Union(expandNodeGroupMembers(child.Name, groupMap, directMembers, path))
+	}
+	return members
+}
+
+// GetOverlappingNodes returns nodes matched by the nodegroup which are also matched by
+// other nodegroups, along with names of these nodegroups sorted by name.
+func GetOverlappingNodes(nodes []corev1.Node, group *groupv1alpha1.NodeGroup, groups []groupv1alpha1.NodeGroup) (map[string][]string, error) {
+	members, _, err := MatchNodesInGroup(nodes, group)
+	if err != nil {
+		return nil, err
+	}
+	memberSet := sets.NewString()
+	for i := range members {
+		memberSet.Insert(members[i].Name)
+	}
+
+	overlapping := make(map[string][]string)
+	for _, other := range sortNodeGroupsByName(groups) {
+		if other.Name == group.Name {
+			continue
+		}
+		otherMembers, _, err := MatchNodesInGroup(nodes, other)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match nodes for nodegroup %s, %v", other.Name, err)
+		}
+		for i := range otherMembers {
+			if memberSet.Has(otherMembers[i].Name) {
+				overlapping[otherMembers[i].Name] = append(overlapping[otherMembers[i].Name], other.Name)
+			}
+		}
+	}
+	return overlapping, nil
+}
+
+// GetExclusiveOverlappingNodes returns nodes matched by the nodegroup which are also matched by
+// other exclusive nodegroups. Nodes shared by an exclusive nodegroup with non-exclusive ones are
+// owned by the exclusive nodegroup, so they are not conflicts.
+func GetExclusiveOverlappingNodes(nodes []corev1.Node, group *groupv1alpha1.NodeGroup, groups []groupv1alpha1.NodeGroup) (map[string][]string, error) {
+	exclusiveGroups := []groupv1alpha1.NodeGroup{}
+	for i := range groups {
+		if groups[i].Spec.Exclusive {
+			exclusiveGroups = append(exclusiveGroups, groups[i])
+		}
+	}
+	return GetOverlappingNodes(nodes, group, exclusiveGroups)
+}
+
+// ValidateExclusiveNodeGroup checks that the nodegroup, if exclusive, does not match
+// any node that is also matched by other exclusive nodegroups.
+func ValidateExclusiveNodeGroup(nodes []corev1.Node, group *groupv1alpha1.NodeGroup, groups []groupv1alpha1.NodeGroup) error {
+	if !group.Spec.Exclusive {
+		return nil
+	}
+
+	overlapping, err := GetExclusiveOverlappingNodes(nodes, group, groups)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) != 0 {
+		return fmt.Errorf("exclusive nodegroup %s overlaps with other exclusive nodegroups on nodes: %s",
+			group.Name, FormatOverlappingNodes(overlapping))
+	}
+	return nil
+}
+
+// FormatOverlappingNodes formats overlapping nodes as "node1(group1,group2), node2(group3)".
+func FormatOverlappingNodes(overlapping map[string][]string) string {
+	nodes := make([]string, 0, len(overlapping))
+	for node := range overlapping {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	formatted := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		formatted = append(formatted, fmt.Sprintf("%s(%s)", node, strings.Join(overlapping[node], ",")))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// GetTargetNodeGroupNames returns names of target nodegroups of the policy in the order they are listed.
+func GetTargetNodeGroupNames(policy *policyv1alpha1.PropagationPolicy) []string {
+	names := []string{}
+	for _, weight := range policy.Spec.Placement.StaticWeightList {
+		names = append(names, weight.NodeGroupNames...)
+	}
+	return names
+}
+
+// GetPlacementNodeGroupNames returns names of nodegroups where pods are placed by the policy. If the
+// policy splits pods by child nodegroups, descendants of each target nodegroup are listed before it,
+// so that a node is counted in the deepest nodegroup it belongs to.
+func GetPlacementNodeGroupNames(policy *policyv1alpha1.PropagationPolicy, groups []groupv1alpha1.NodeGroup) []string {
+	targets := GetTargetNodeGroupNames(policy)
+	if !policy.Spec.Placement.SplitByChildGroups {
+		return targets
+	}
+
+	groupMap := make(map[string]*groupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+	names := []string{}
+	visited := sets.NewString()
+	var visit func(name string)
+	visit = func(name string) {
+		if visited.Has(name) {
+			return
+		}
+		visited.Insert(name)
+		if group, ok := groupMap[name]; ok {
+			for _, child := range group.Spec.ChildGroups {
+				visit(child.Name)
+			}
+		}
+		names = append(names, name)
+	}
+	for _, name := range targets {
+		visit(name)
+	}
+	return names
+}
+
+// DesiredPodsNumOfPolicy returns the desired number of pods in each nodegroup where pods are placed
+// by the policy. If the policy splits pods by child nodegroups, pods desired in a nodegroup are split
+// across its existing child nodegroups recursively with weights of the child nodegroups. Failed over
+// nodegroups in the status of the policy get no pod, their share is redistributed to other target
+// nodegroups by weight, unless all target nodegroups are failed over.
+func DesiredPodsNumOfPolicy(policy *policyv1alpha1.PropagationPolicy, groups []groupv1alpha1.NodeGroup, replicaNum int32) map[string]int32 {
+	desired := DesiredPodsNumInTargetNodeGroups(getAvailableWeights(policy), replicaNum)
+	if !policy.Spec.Placement.SplitByChildGroups {
+		return desired
+	}
+
+	groupMap := make(map[string]*groupv1alpha1.NodeGroup, len(groups))
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+	results := make(map[string]int32)
+	for name, num := range desired {
+		splitPodsNumToChildGroups(name, num, groupMap, sets.NewString(), results)
+	}
+	return results
+}
+
+// getAvailableWeights returns static weights of the policy without failed over nodegroups.
+func getAvailableWeights(policy *policyv1alpha1.PropagationPolicy) []policyv1alpha1.StaticNodeGroupWeight {
+	if len(policy.Status.FailedOverNodeGroups) == 0 {
+		return policy.Spec.Placement.StaticWeightList
+	}
+
+	failedOver := sets.NewString()
+	for _, group := range policy.Status.FailedOverNodeGroups {
+		failedOver.Insert(group.Name)
+	}
+	weights := []policyv1alpha1.StaticNodeGroupWeight{}
+	for _, weight := range policy.Spec.Placement.StaticWeightList {
+		if len(weight.NodeGroupNames) != 0 && failedOver.Has(weight.NodeGroupNames[0]) {
+			continue
+		}
+		weights = append(weights, weight)
+	}
+	if len(weights) == 0 {
+		// nowhere to redistribute pods
+		return policy.Spec.Placement.StaticWeightList
+	}
+	return weights
+}
+
+func splitPodsNumToChildGroups(name string, num int32, groupMap map[string]*groupv1alpha1.NodeGroup, path sets.String, results map[string]int32) {
+	weights := []policyv1alpha1.StaticNodeGroupWeight{}
+	if group, ok := groupMap[name]; ok {
+		for _, child := range group.Spec.ChildGroups {
+			if _, ok := groupMap[child.Name]; !ok || path.Has(child.Name) || child.Name == name {
+				continue
+			}
+			weights = append(weights, policyv1alpha1.StaticNodeGroupWeight{
+				NodeGroupNames: []string{child.Name},
+				Weight:         child.Weight,
+			})
+		}
+	}
+	if len(weights) == 0 {
+		results[name] += num
+		return
+	}
+
+	path.Insert(name)
+	defer path.Delete(name)
+	for child, childNum := range DesiredPodsNumInTargetNodeGroups(weights, num) {
+		splitPodsNumToChildGroups(child, childNum, groupMap, path, results)
+	}
+}
+
+// SortNodeGroupsByPolicy returns the nodegroups with nodegroups where pods are placed by the policy
+// at the front, in the order returned by GetPlacementNodeGroupNames.
+func SortNodeGroupsByPolicy(groups []groupv1alpha1.NodeGroup, policy *policyv1alpha1.PropagationPolicy) []groupv1alpha1.NodeGroup {
+	rank := make(map[string]int)
+	for i, name := range GetPlacementNodeGroupNames(policy, groups) {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+
+	sorted := make([]groupv1alpha1.NodeGroup, len(groups))
+	copy(sorted, groups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, iok := rank[sorted[i].Name]
+		rj, jok := rank[sorted[j].Name]
+		if iok && jok {
+			return ri < rj
+		}
+		return iok && !jok
+	})
+	return sorted
+}
+
+// sortNodeGroupsByPrecedence sorts exclusive nodegroups by creation time, followed by
+// non-exclusive nodegroups sorted by name.
+func sortNodeGroupsByPrecedence(groups []groupv1alpha1.NodeGroup) []*groupv1alpha1.NodeGroup {
+	sorted := sortNodeGroupsByName(groups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Spec.Exclusive != sorted[j].Spec.Exclusive {
+			return sorted[i].Spec.Exclusive
+		}
+		if sorted[i].Spec.Exclusive {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return false
+	})
+	return sorted
+}
+
+func sortNodeGroupsByName(groups []groupv1alpha1.NodeGroup) []*groupv1alpha1.NodeGroup {
+	sorted := make([]*groupv1alpha1.NodeGroup, 0, len(groups))
+	for i := range groups {
+		sorted = append(sorted, &groups[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
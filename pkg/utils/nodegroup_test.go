@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func withChildGroups(group groupv1alpha1.NodeGroup, children ...string) groupv1alpha1.NodeGroup {
+	for _, child := range children {
+		group.Spec.ChildGroups = append(group.Spec.ChildGroups, groupv1alpha1.ChildNodeGroup{Name: child, Weight: 1})
+	}
+	return group
+}
+
+func TestGetNodeGroupMembership(t *testing.T) {
+	now := time.Now()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+	}
+	newGroup := func(name string, exclusive bool, created time.Time, nodes ...string) groupv1alpha1.NodeGroup {
+		return groupv1alpha1.NodeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec:       groupv1alpha1.NodeGroupSpec{Nodes: nodes, Exclusive: exclusive},
+		}
+	}
+
+	cases := []struct {
+		name         string
+		groups       []groupv1alpha1.NodeGroup
+		want         map[string][]string
+		wantConflict bool
+	}{
+		{
+			name: "non-exclusive nodegroups share nodes",
+			groups: []groupv1alpha1.NodeGroup{
+				newGroup("hangzhou", false, now, "node1", "node2"),
+				newGroup("beijing", false, now, "node2", "node3"),
+			},
+			want: map[string][]string{
+				"node1": {"hangzhou"},
+				"node2": {"beijing", "hangzhou"},
+				"node3": {"beijing"},
+			},
+		},
+		{
+			name: "exclusive nodegroup owns shared nodes",
+			groups: []groupv1alpha1.NodeGroup{
+				newGroup("hangzhou", false, now, "node1", "node2"),
+				newGroup("beijing", true, now, "node2", "node3"),
+			},
+			want: map[string][]string{
+				"node1": {"hangzhou"},
+				"node2": {"beijing"},
+				"node3": {"beijing"},
+			},
+		},
+		{
+			name: "earliest exclusive nodegroup wins",
+			groups: []groupv1alpha1.NodeGroup{
+				newGroup("hangzhou", true, now, "node1", "node2"),
+				newGroup("beijing", true, now.Add(-time.Hour), "node2", "node3"),
+			},
+			want: map[string][]string{
+				"node1": {"hangzhou"},
+				"node2": {"beijing"},
+				"node3": {"beijing"},
+			},
+			wantConflict: true,
+		},
+		{
+			name: "nodes of child nodegroups belong to ancestors",
+			groups: []groupv1alpha1.NodeGroup{
+				withChildGroups(newGroup("china", false, now), "zhejiang"),
+				withChildGroups(newGroup("zhejiang", false, now, "node3"), "hangzhou"),
+				newGroup("hangzhou", true, now, "node1", "node2"),
+			},
+			want: map[string][]string{
+				"node1": {"china", "hangzhou", "zhejiang"},
+				"node2": {"china", "hangzhou", "zhejiang"},
+				"node3": {"china", "zhejiang"},
+			},
+		},
+		{
+			name: "cycle of child nodegroups is ignored",
+			groups: []groupv1alpha1.NodeGroup{
+				withChildGroups(newGroup("hangzhou", false, now, "node1"), "beijing"),
+				withChildGroups(newGroup("beijing", false, now, "node2"), "hangzhou"),
+			},
+			want: map[string][]string{
+				"node1": {"beijing", "hangzhou"},
+				"node2": {"beijing", "hangzhou"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		membership, err := GetNodeGroupMembership(nodes, c.groups)
+		if err != nil {
+			t.Errorf("case: %s, unexpected error: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(membership, c.want) {
+			t.Errorf("case: %s, want membership %v but get %v", c.name, c.want, membership)
+		}
+		for i := range c.groups {
+			err := ValidateExclusiveNodeGroup(nodes, &c.groups[i], c.groups)
+			if c.wantConflict && err == nil {
+				t.Errorf("case: %s, want error for overlapping exclusive nodegroup %s", c.name, c.groups[i].Name)
+			}
+			if !c.wantConflict && err != nil {
+				t.Errorf("case: %s, unexpected error: %v", c.name, err)
+			}
+		}
+	}
+}
+
+func TestDesiredPodsNumOfPolicy(t *testing.T) {
+	newGroup := func(name string, children ...groupv1alpha1.ChildNodeGroup) groupv1alpha1.NodeGroup {
+		return groupv1alpha1.NodeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       groupv1alpha1.NodeGroupSpec{ChildGroups: children},
+		}
+	}
+	groups := []groupv1alpha1.NodeGroup{
+		newGroup("east", groupv1alpha1.ChildNodeGroup{Name: "hangzhou", Weight: 1}, groupv1alpha1.ChildNodeGroup{Name: "shanghai", Weight: 3}),
+		newGroup("north", groupv1alpha1.ChildNodeGroup{Name: "beijing", Weight: 1}, groupv1alpha1.ChildNodeGroup{Name: "missing", Weight: 1}),
+		newGroup("hangzhou"),
+		newGroup("shanghai"),
+		newGroup("beijing"),
+	}
+	weights := []policyv1alpha1.StaticNodeGroupWeight{
+		{NodeGroupNames: []string{"east"}, Weight: 2},
+		{NodeGroupNames: []string{"north"}, Weight: 1},
+	}
+
+	cases := []struct {
+		name       string
+		split      bool
+		failedOver []string
+		want       map[string]int32
+	}{
+		{
+			name:  "not split by child nodegroups",
+			split: false,
+			want:  map[string]int32{"east": 8, "north": 4},
+		},
+		{
+			name:  "split by child nodegroups",
+			split: true,
+			want:  map[string]int32{"hangzhou": 2, "shanghai": 6, "beijing": 4},
+		},
+		{
+			name:       "share of failed over nodegroup is redistributed",
+			split:      true,
+			failedOver: []string{"north"},
+			want:       map[string]int32{"hangzhou": 3, "shanghai": 9},
+		},
+		{
+			name:       "all target nodegroups are failed over",
+			split:      false,
+			failedOver: []string{"east", "north"},
+			want:       map[string]int32{"east": 8, "north": 4},
+		},
+	}
+
+	for _, c := range cases {
+		policy := &policyv1alpha1.PropagationPolicy{
+			Spec: policyv1alpha1.PropagationPolicySpec{
+				Placement: policyv1alpha1.NodeGroupPreferences{
+					StaticWeightList:   weights,
+					SplitByChildGroups: c.split,
+				},
+			},
+		}
+		for _, name := range c.failedOver {
+			policy.Status.FailedOverNodeGroups = append(policy.Status.FailedOverNodeGroups, policyv1alpha1.FailedOverNodeGroup{Name: name})
+		}
+		desired := DesiredPodsNumOfPolicy(policy, groups, 12)
+		if !reflect.DeepEqual(desired, c.want) {
+			t.Errorf("case: %s, want %v but get %v", c.name, c.want, desired)
+		}
+	}
+}
+
+func TestDesiredPodsNumOfPolicyWithZeroChildWeight(t *testing.T) {
+	groups := []groupv1alpha1.NodeGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec: groupv1alpha1.NodeGroupSpec{ChildGroups: []groupv1alpha1.ChildNodeGroup{
+				{Name: "a", Weight: 0}, {Name: "b", Weight: 1}, {Name: "c", Weight: 1}, {Name: "d", Weight: 1},
+			}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "d"}},
+	}
+	policy := &policyv1alpha1.PropagationPolicy{
+		Spec: policyv1alpha1.PropagationPolicySpec{
+			Placement: policyv1alpha1.NodeGroupPreferences{
+				StaticWeightList:   []policyv1alpha1.StaticNodeGroupWeight{{NodeGroupNames: []string{"east"}, Weight: 1}},
+				SplitByChildGroups: true,
+			},
+		},
+	}
+
+	// the left pod goes to b instead of a which has zero weight
+	want := map[string]int32{"a": 0, "b": 2, "c": 1, "d": 1}
+	desired := DesiredPodsNumOfPolicy(policy, groups, 4)
+	if !reflect.DeepEqual(desired, want) {
+		t.Errorf("want %v but get %v", want, desired)
+	}
+}
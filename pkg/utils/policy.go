@@ -0,0 +1,288 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+// ConvertClusterPropagationPolicy returns the ClusterPropagationPolicy as a PropagationPolicy without
+// namespace, so that cluster-wide policies can be handled the same way as namespaced ones.
+func ConvertClusterPropagationPolicy(policy *policyv1alpha1.ClusterPropagationPolicy) *policyv1alpha1.PropagationPolicy {
+	return &policyv1alpha1.PropagationPolicy{
+		ObjectMeta: policy.ObjectMeta,
+		Spec:       policy.Spec,
+		Status:     policy.Status,
+	}
+}
+
+// PropagationPolicyObject returns the object holding the policy, which is the ClusterPropagationPolicy
+// the policy is converted from if it has no namespace. Updates and events of the policy should go
+// to this object.
+func PropagationPolicyObject(policy *policyv1alpha1.PropagationPolicy) runtimeClient.Object {
+	if policy.Namespace != "" {
+		return policy
+	}
+	return &policyv1alpha1.ClusterPropagationPolicy{
+		ObjectMeta: policy.ObjectMeta,
+		Spec:       policy.Spec,
+		Status:     policy.Status,
+	}
+}
+
+// ConvertClusterOverridePolicy returns the ClusterOverridePolicy as an OverridePolicy without namespace.
+func ConvertClusterOverridePolicy(policy *policyv1alpha1.ClusterOverridePolicy) *policyv1alpha1.OverridePolicy {
+	return &policyv1alpha1.OverridePolicy{
+		ObjectMeta: policy.ObjectMeta,
+		Spec:       policy.Spec,
+		Status:     policy.Status,
+	}
+}
+
+// OverridePolicyObject returns the object holding the policy, which is the ClusterOverridePolicy
+// the policy is converted from if it has no namespace.
+func OverridePolicyObject(policy *policyv1alpha1.OverridePolicy) runtimeClient.Object {
+	if policy.Namespace != "" {
+		return policy
+	}
+	return &policyv1alpha1.ClusterOverridePolicy{
+		ObjectMeta: policy.ObjectMeta,
+		Spec:       policy.Spec,
+		Status:     policy.Status,
+	}
+}
+
+// FormatPolicy formats the policy as "<Kind> <namespace>/<name>", or "<ClusterKind> <name>"
+// if it has no namespace.
+func FormatPolicy(kind string, policy metav1.Object) string {
+	if policy.GetNamespace() == "" {
+		return fmt.Sprintf("Cluster%s %s", kind, policy.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, policy.GetNamespace(), policy.GetName())
+}
+
+// ListPropagationPolicies returns PropagationPolicies in the namespace, or in all namespaces if the
+// namespace is empty, along with ClusterPropagationPolicies converted by ConvertClusterPropagationPolicy.
+// Policies being deleted are skipped, and defaults are set. Policies are sorted by precedence
+// as defined by HasPropagationPrecedence.
+func ListPropagationPolicies(ctx context.Context, client runtimeClient.Client, namespace string) ([]policyv1alpha1.PropagationPolicy, error) {
+	policyList := &policyv1alpha1.PropagationPolicyList{}
+	if err := client.List(ctx, policyList, runtimeClient.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list propagationpolicies, %v", err)
+	}
+	clusterPolicyList := &policyv1alpha1.ClusterPropagationPolicyList{}
+	if err := client.List(ctx, clusterPolicyList); err != nil {
+		return nil, fmt.Errorf("failed to list clusterpropagationpolicies, %v", err)
+	}
+
+	policies := make([]policyv1alpha1.PropagationPolicy, 0, len(policyList.Items)+len(clusterPolicyList.Items))
+	policies = append(policies, policyList.Items...)
+	for i := range clusterPolicyList.Items {
+		policies = append(policies, *ConvertClusterPropagationPolicy(&clusterPolicyList.Items[i]))
+	}
+
+	results := make([]policyv1alpha1.PropagationPolicy, 0, len(policies))
+	for i := range policies {
+		if policies[i].DeletionTimestamp != nil {
+			// workloads of the policy are being restored or taken over by other policies
+			continue
+		}
+		policyv1alpha1.SetDefaultsPropagationPolicy(&policies[i])
+		results = append(results, policies[i])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return HasPropagationPrecedence(&results[i], &results[j])
+	})
+	return results, nil
+}
+
+// ListOverridePolicies returns OverridePolicies in the namespace, or in all namespaces if the
+// namespace is empty, along with ClusterOverridePolicies converted by ConvertClusterOverridePolicy.
+// Defaults are set and policies are sorted by precedence.
+func ListOverridePolicies(ctx context.Context, client runtimeClient.Client, namespace string) ([]policyv1alpha1.OverridePolicy, error) {
+	policyList := &policyv1alpha1.OverridePolicyList{}
+	if err := client.List(ctx, policyList, runtimeClient.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list overridepolicies, %v", err)
+	}
+	clusterPolicyList := &policyv1alpha1.ClusterOverridePolicyList{}
+	if err := client.List(ctx, clusterPolicyList); err != nil {
+		return nil, fmt.Errorf("failed to list clusteroverridepolicies, %v", err)
+	}
+
+	policies := make([]policyv1alpha1.OverridePolicy, 0, len(policyList.Items)+len(clusterPolicyList.Items))
+	policies = append(policies, policyList.Items...)
+	for i := range clusterPolicyList.Items {
+		policies = append(policies, *ConvertClusterOverridePolicy(&clusterPolicyList.Items[i]))
+	}
+	for i := range policies {
+		policyv1alpha1.SetDefaultsOverridePolicy(&policies[i])
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return hasPrecedence(&policies[i], &policies[j])
+	})
+	return policies, nil
+}
+
+// hasPrecedence returns true if policy a takes precedence over policy b. Namespaced policies
+// take precedence over cluster-scoped ones. Otherwise, the earlier created one takes precedence.
+func hasPrecedence(a, b metav1.Object) bool {
+	if aNamespaced, bNamespaced := a.GetNamespace() != "", b.GetNamespace() != ""; aNamespaced != bNamespaced {
+		return aNamespaced
+	}
+	aCreated, bCreated := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !aCreated.Equal(&bCreated) {
+		return aCreated.Before(&bCreated)
+	}
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// HasPropagationPrecedence returns true if PropagationPolicy a takes precedence over PropagationPolicy b.
+// The policy with the higher priority takes precedence. Among policies with the same priority, namespaced
+// policies take precedence over cluster-scoped ones, and then the one with the smaller namespace and name.
+// Unlike hasPrecedence, the creation time is not considered, so that the order does not change when a
+// policy is recreated. On a specific workload, the more specific selector is compared before namespace
+// and name, see GetPolicyOfWorkload.
+func HasPropagationPrecedence(a, b *policyv1alpha1.PropagationPolicy) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority > b.Spec.Priority
+	}
+	if aNamespaced, bNamespaced := a.Namespace != "", b.Namespace != ""; aNamespaced != bNamespaced {
+		return aNamespaced
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// Specificity of resource selectors selecting a workload, from the least specific.
+const (
+	selectorNotMatched = iota
+	selectorMatchedByKind
+	selectorMatchedByLabels
+	selectorMatchedByName
+)
+
+// getSelectorSpecificity returns how specifically the resource selectors select the deployment, which
+// is the most specific one of the selectors selecting it by name, by labelSelector, or all deployments
+// in the namespace if neither is specified.
+func getSelectorSpecificity(selectors []policyv1alpha1.ResourceSelector, deploy metav1.Object) int {
+	specificity := selectorNotMatched
+	for _, selector := range selectors {
+		if selector.Kind != "Deployment" || selector.Namespace != deploy.GetNamespace() {
+			continue
+		}
+		if selector.Name != "" {
+			if selector.Name == deploy.GetName() {
+				return selectorMatchedByName
+			}
+			continue
+		}
+		if selector.LabelSelector == nil {
+			if specificity < selectorMatchedByKind {
+				specificity = selectorMatchedByKind
+			}
+			continue
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			klog.Errorf("invalid label selector of resource selector in namespace %s, %v", selector.Namespace, err)
+			continue
+		}
+		if labelSelector.Matches(labels.Set(deploy.GetLabels())) {
+			specificity = selectorMatchedByLabels
+		}
+	}
+	return specificity
+}
+
+// SelectsWorkload returns true if one of the resource selectors selects the deployment by name, by
+// labelSelector, or selects all deployments in its namespace.
+func SelectsWorkload(selectors []policyv1alpha1.ResourceSelector, workload metav1.Object) bool {
+	return getSelectorSpecificity(selectors, workload) != selectorNotMatched
+}
+
+// GetPolicyOfWorkload returns the policy propagating the workload, which is the policy with the
+// highest precedence among policies selecting it. Between policies with the same priority and
+// scope, the one whose selector selects the workload more specifically takes precedence, which is
+// by name, then by labelSelector, then all deployments in the namespace. The remaining ties are
+// broken by namespace and name. It returns nil if no policy selects the workload.
+func GetPolicyOfWorkload(ctx context.Context, client runtimeClient.Client, workload metav1.Object) (*policyv1alpha1.PropagationPolicy, error) {
+	policies, err := ListPropagationPolicies(ctx, client, workload.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	var owner *policyv1alpha1.PropagationPolicy
+	ownerSpecificity := selectorNotMatched
+	for i := range policies {
+		policy := &policies[i]
+		specificity := getSelectorSpecificity(policy.Spec.ResourceSelectors, workload)
+		if specificity == selectorNotMatched {
+			continue
+		}
+		// policies are sorted by precedence, so a later one only wins with a more specific
+		// selector at the same priority and scope
+		if owner == nil || (policy.Spec.Priority == owner.Spec.Priority &&
+			(policy.Namespace == "") == (owner.Namespace == "") && specificity > ownerSpecificity) {
+			owner, ownerSpecificity = policy, specificity
+		}
+	}
+	return owner, nil
+}
+
+// PolicyKey returns the key of the policy recorded in PropagationPolicyAnnotation, which is
+// "<namespace>/<name>", or "<name>" if the policy has no namespace.
+func PolicyKey(policy metav1.Object) string {
+	if policy.GetNamespace() == "" {
+		return policy.GetName()
+	}
+	return policy.GetNamespace() + "/" + policy.GetName()
+}
+
+// GetPolicyByKey gets the PropagationPolicy with the key returned by PolicyKey, or the
+// ClusterPropagationPolicy if the key has no namespace, and sets its defaults. It returns nil
+// if the policy does not exist or is being deleted.
+func GetPolicyByKey(ctx context.Context, client runtimeClient.Client, key string) (*policyv1alpha1.PropagationPolicy, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *policyv1alpha1.PropagationPolicy
+	if namespace != "" {
+		policy = &policyv1alpha1.PropagationPolicy{}
+		err = client.Get(ctx, runtimeClient.ObjectKey{Namespace: namespace, Name: name}, policy)
+	} else {
+		clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{}
+		err = client.Get(ctx, runtimeClient.ObjectKey{Name: name}, clusterPolicy)
+		policy = ConvertClusterPropagationPolicy(clusterPolicy)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get policy %s, %v", key, err)
+	}
+	if policy.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	policyv1alpha1.SetDefaultsPropagationPolicy(policy)
+	return policy, nil
+}
+
+// IsSamePolicy returns true if both policies have the same namespace and name.
+func IsSamePolicy(a, b metav1.Object) bool {
+	return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func TestHasPropagationPrecedence(t *testing.T) {
+	newPolicy := func(namespace, name string, priority int32) policyv1alpha1.PropagationPolicy {
+		return policyv1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       policyv1alpha1.PropagationPolicySpec{Priority: priority},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		policies []policyv1alpha1.PropagationPolicy
+		want     []string
+	}{
+		{
+			name: "higher priority wins",
+			policies: []policyv1alpha1.PropagationPolicy{
+				newPolicy("default", "a", 0),
+				newPolicy("", "b", 10),
+			},
+			want: []string{"/b", "default/a"},
+		},
+		{
+			name: "namespaced policy wins with the same priority",
+			policies: []policyv1alpha1.PropagationPolicy{
+				newPolicy("", "a", 1),
+				newPolicy("default", "b", 1),
+			},
+			want: []string{"default/b", "/a"},
+		},
+		{
+			name: "smaller namespace and name win",
+			policies: []policyv1alpha1.PropagationPolicy{
+				newPolicy("kube", "a", 0),
+				newPolicy("default", "c", 0),
+				newPolicy("default", "b", 0),
+			},
+			want: []string{"default/b", "default/c", "kube/a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sort.Slice(c.policies, func(i, j int) bool {
+				return HasPropagationPrecedence(&c.policies[i], &c.policies[j])
+			})
+			for i := range c.policies {
+				if got := c.policies[i].Namespace + "/" + c.policies[i].Name; got != c.want[i] {
+					t.Errorf("policy %d: want %s, got %s", i, c.want[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPolicyOfWorkload(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deploy", Labels: map[string]string{"app": "web"}},
+	}
+	byName := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy"}
+	byLabels := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment",
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}
+	byKind := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment"}
+	otherLabels := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment",
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}}
+	otherKind := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "deploy"}
+	newPolicy := func(name string, priority int32, selectors ...policyv1alpha1.ResourceSelector) runtimeClient.Object {
+		return &policyv1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       policyv1alpha1.PropagationPolicySpec{Priority: priority, ResourceSelectors: selectors},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		policies []runtimeClient.Object
+		want     string
+	}{
+		{
+			name:     "selector by name wins over labelSelector",
+			policies: []runtimeClient.Object{newPolicy("a", 0, byLabels), newPolicy("b", 0, byName)},
+			want:     "default/b",
+		},
+		{
+			name:     "labelSelector wins over selector of the kind",
+			policies: []runtimeClient.Object{newPolicy("a", 0, byKind), newPolicy("b", 0, byLabels)},
+			want:     "default/b",
+		},
+		{
+			name:     "the most specific matching selector of a policy is compared",
+			policies: []runtimeClient.Object{newPolicy("a", 0, byLabels), newPolicy("b", 0, byKind, byName)},
+			want:     "default/b",
+		},
+		{
+			name:     "name breaks ties of the same specificity",
+			policies: []runtimeClient.Object{newPolicy("b", 0, byLabels), newPolicy("a", 0, byLabels)},
+			want:     "default/a",
+		},
+		{
+			name:     "priority is compared before specificity",
+			policies: []runtimeClient.Object{newPolicy("a", 0, byName), newPolicy("b", 1, byKind)},
+			want:     "default/b",
+		},
+		{
+			name:     "selectors not matching the workload",
+			policies: []runtimeClient.Object{newPolicy("a", 0, otherLabels), newPolicy("b", 0, otherKind)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client := newFakeClient(t, c.policies...)
+			policy, err := GetPolicyOfWorkload(context.TODO(), client, deploy)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			got := ""
+			if policy != nil {
+				got = PolicyKey(policy)
+			}
+			if got != c.want {
+				t.Errorf("want policy %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestGetRelativeDeployAndPolicyWithAnnotation(t *testing.T) {
+	selector := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "deploy"}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "deploy",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "deploy"}},
+		},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "deploy-rs",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deploy, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "deploy-rs-pod",
+			Labels:          map[string]string{"app": "deploy"},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rs, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))},
+		},
+	}
+	clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec:       policyv1alpha1.PropagationPolicySpec{ResourceSelectors: []policyv1alpha1.ResourceSelector{selector}},
+	}
+	// the namespaced policy takes precedence over the recorded one but is not used
+	// until the controller records it
+	policy := &policyv1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+		Spec:       policyv1alpha1.PropagationPolicySpec{ResourceSelectors: []policyv1alpha1.ResourceSelector{selector}},
+	}
+
+	cases := []struct {
+		name             string
+		deployAnnotation string
+		podAnnotation    string
+		objs             []runtimeClient.Object
+		want             string
+	}{
+		{
+			name:             "policy recorded on the deployment",
+			deployAnnotation: "policy",
+			objs:             []runtimeClient.Object{rs, clusterPolicy, policy},
+			want:             "policy",
+		},
+		{
+			name:          "policy recorded on the pod",
+			podAnnotation: "policy",
+			objs:          []runtimeClient.Object{rs, clusterPolicy, policy},
+			want:          "policy",
+		},
+		{
+			name:             "pod of old replicaset recorded with the previous policy",
+			deployAnnotation: "policy",
+			podAnnotation:    "default/policy",
+			objs:             []runtimeClient.Object{rs, clusterPolicy, policy},
+			want:             "policy",
+		},
+		{
+			name: "no policy recorded",
+			objs: []runtimeClient.Object{rs, clusterPolicy, policy},
+			want: "default/policy",
+		},
+		{
+			name:             "recorded policy does not exist",
+			deployAnnotation: "policy",
+			objs:             []runtimeClient.Object{rs, policy},
+			want:             "default/policy",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := deploy.DeepCopy()
+			if c.deployAnnotation != "" {
+				d.Annotations = map[string]string{policyv1alpha1.PropagationPolicyAnnotation: c.deployAnnotation}
+			}
+			client := newFakeClient(t, append(c.objs, d)...)
+			p := pod.DeepCopy()
+			if c.podAnnotation != "" {
+				p.Annotations = map[string]string{policyv1alpha1.PropagationPolicyAnnotation: c.podAnnotation}
+			}
+			gotDeploy, gotPolicy, err := GetRelativeDeployAndPolicy(context.TODO(), client, p)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			if gotDeploy == nil || gotDeploy.Name != deploy.Name {
+				t.Errorf("want deployment %s, got %v", deploy.Name, gotDeploy)
+			}
+			if gotPolicy == nil || PolicyKey(gotPolicy) != c.want {
+				t.Errorf("want policy %s, got %v", c.want, gotPolicy)
+			}
+		})
+	}
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
+	"sort"
 
 	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	apierr "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/klog/v2"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,68 +31,252 @@ func WithCheck(handler http.Handler) http.Handler {
 	})
 }
 
+// GetNodesInGroups returns the map from node name to the name of the nodegroup in groups
+// the node belongs to. If a node belongs to more than one of the groups, it is assigned to
+// the one that comes first in groups. Nodes owned by exclusive nodegroups are only assigned
+// to their owners, which means they will be absent in the result if the owner is not in groups.
 func GetNodesInGroups(ctx context.Context, client runtimeClient.Client, groups []groupv1alpha1.NodeGroup) (map[string]string, error) {
+	nodeList := &corev1.NodeList{}
+	if err := client.List(ctx, nodeList); err != nil {
+		klog.Errorf("failed to list nodes for nodegroups, %v", err)
+		return nil, err
+	}
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := client.List(ctx, groupList); err != nil {
+		klog.Errorf("failed to list nodegroups, %v", err)
+		return nil, err
+	}
+
+	membership, err := GetNodeGroupMembership(nodeList.Items, groupList.Items)
+	if err != nil {
+		return nil, err
+	}
+
 	nodesInGroups := make(map[string]string)
-	for _, group := range groups {
-		labelSelector := metav1.SetAsLabelSelector(group.Spec.MatchLabels)
-		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
-		if err != nil {
-			klog.Errorf("failed to get list selector according to matchLabels of nodegroup: %s, err %v", group.Name, err)
-			return nil, err
-		}
-		nodeList := &corev1.NodeList{}
-		if err := client.List(ctx, nodeList, &runtimeClient.ListOptions{LabelSelector: selector}); err != nil {
-			klog.Errorf("failed to list node for nodegroup %s, %v", group.Name, err)
-			return nil, err
+	for node, belongings := range membership {
+		belongingSet := sets.NewString(belongings...)
+		for i := range groups {
+			if belongingSet.Has(groups[i].Name) {
+				nodesInGroups[node] = groups[i].Name
+				break
+			}
 		}
-		for i := range nodeList.Items {
-			nodesInGroups[nodeList.Items[i].Name] = group.Name
+		if len(belongings) > 1 {
+			klog.V(4).Infof("node %s belongs to nodegroups %v, assign it to nodegroup %s", node, belongings, nodesInGroups[node])
 		}
 	}
 
 	return nodesInGroups, nil
 }
 
-func GetNodeGroupsWithName(ctx context.Context, client runtimeClient.Client, nodeGroupName []string) ([]groupv1alpha1.NodeGroup, error) {
+// MatchNodesInGroup returns nodes that belong to the nodegroup, which are nodes listed
+// in Spec.Nodes and nodes matched by the selectors of the nodegroup. It also returns names
+// of nodes listed in Spec.Nodes that cannot be found in the given nodes.
+func MatchNodesInGroup(nodes []corev1.Node, group *groupv1alpha1.NodeGroup) ([]corev1.Node, []string, error) {
+	matcher, err := newNodeGroupMatcher(group)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := []corev1.Node{}
+	foundNodes := sets.NewString()
+	for i := range nodes {
+		if matcher.nodes.Has(nodes[i].Name) {
+			foundNodes.Insert(nodes[i].Name)
+		}
+		if matcher.matches(&nodes[i]) {
+			members = append(members, nodes[i])
+		}
+	}
+
+	return members, matcher.nodes.Difference(foundNodes).List(), nil
+}
+
+// IsNodeInGroup returns true if the node belongs to the nodegroup.
+func IsNodeInGroup(node *corev1.Node, group *groupv1alpha1.NodeGroup) (bool, error) {
+	matcher, err := newNodeGroupMatcher(group)
+	if err != nil {
+		return false, err
+	}
+	return matcher.matches(node), nil
+}
+
+// nodeGroupMatcher decides whether a node belongs to a nodegroup.
+type nodeGroupMatcher struct {
+	nodes    sets.String
+	selector labels.Selector
+	taints   []groupv1alpha1.TaintSelector
+}
+
+func newNodeGroupMatcher(group *groupv1alpha1.NodeGroup) (*nodeGroupMatcher, error) {
+	matcher := &nodeGroupMatcher{
+		nodes:    sets.NewString(group.Spec.Nodes...),
+		selector: labels.Nothing(),
+		taints:   group.Spec.MatchTaints,
+	}
+
+	if len(group.Spec.MatchLabels) == 0 && group.Spec.LabelSelector == nil && len(group.Spec.MatchTaints) == 0 {
+		return matcher, nil
+	}
+
+	labelSelector := &metav1.LabelSelector{}
+	if group.Spec.LabelSelector != nil {
+		labelSelector = group.Spec.LabelSelector.DeepCopy()
+	}
+	if len(group.Spec.MatchLabels) != 0 && labelSelector.MatchLabels == nil {
+		labelSelector.MatchLabels = make(map[string]string, len(group.Spec.MatchLabels))
+	}
+	for k, v := range group.Spec.MatchLabels {
+		if old, ok := labelSelector.MatchLabels[k]; ok && old != v {
+			// the same key with different values can never be matched
+			return matcher, nil
+		}
+		labelSelector.MatchLabels[k] = v
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector of nodegroup %s, %v", group.Name, err)
+	}
+	matcher.selector = selector
+	return matcher, nil
+}
+
+func (m *nodeGroupMatcher) matches(node *corev1.Node) bool {
+	if m.nodes.Has(node.Name) {
+		return true
+	}
+	if !m.selector.Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for _, taintSelector := range m.taints {
+		if !nodeHasTaint(node, taintSelector) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeHasTaint(node *corev1.Node, taintSelector groupv1alpha1.TaintSelector) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key != taintSelector.Key {
+			continue
+		}
+		if taintSelector.Value != "" && taint.Value != taintSelector.Value {
+			continue
+		}
+		if taintSelector.Effect != "" && taint.Effect != taintSelector.Effect {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// GetPodRequests returns the cpu and memory requested by the pod, which is the larger one
+// of the sum of requests of all containers and the request of any init container,
+// plus the pod overhead.
+func GetPodRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		AddResourceList(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			quantity, ok := container.Resources.Requests[name]
+			if !ok {
+				continue
+			}
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	AddResourceList(requests, pod.Spec.Overhead)
+
+	result := corev1.ResourceList{}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if quantity, ok := requests[name]; ok {
+			result[name] = quantity
+		}
+	}
+	return result
+}
+
+// AddResourceList adds quantities in toAdd to list.
+func AddResourceList(list, toAdd corev1.ResourceList) {
+	for name, quantity := range toAdd {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// GetNodeGroupsWithName returns the nodegroups with the given names, along with names of
+// nodegroups which do not exist.
+func GetNodeGroupsWithName(ctx context.Context, client runtimeClient.Client, nodeGroupName []string) ([]groupv1alpha1.NodeGroup, []string, error) {
 	nodegroup := []groupv1alpha1.NodeGroup{}
+	missing := []string{}
 	for _, name := range nodeGroupName {
 		group := &groupv1alpha1.NodeGroup{}
-		// TODO:
-		// do not use "default" as nodegroup namespace"
-		if err := client.Get(ctx, runtimeClient.ObjectKey{Name: name, Namespace: "default"}, group); err != nil {
+		// NodeGroup is cluster-scoped
+		if err := client.Get(ctx, runtimeClient.ObjectKey{Name: name}, group); err != nil {
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, name)
+				continue
+			}
 			klog.Errorf("failed to get group obj %s, %v", name, err)
-			return nil, err
+			return nil, nil, err
 		}
 		nodegroup = append(nodegroup, *group)
 	}
-	return nodegroup, nil
+	return nodegroup, missing, nil
 }
 
+// GetManifestsDeploys returns deployments selected by resource selectors of the policy, see SelectsWorkload.
+// Each deployment is returned once even if it is selected by multiple selectors.
 func GetManifestsDeploys(ctx context.Context, client runtimeClient.Client, policy *policyv1alpha1.PropagationPolicy) ([]*appsv1.Deployment, error) {
 	deploys := []*appsv1.Deployment{}
+	selected := sets.NewString()
 	errs := []error{}
 	for _, selector := range policy.Spec.ResourceSelectors {
-		deploy := &appsv1.Deployment{}
-		key := types.NamespacedName{Namespace: selector.Namespace, Name: selector.Name}
-		err := client.Get(ctx, key, deploy)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to get deployment namespace: %s name: %s, %v", selector.Namespace, selector.Name, err))
+		if selector.Kind != "Deployment" {
+			klog.Warningf("resource selector of kind %s in %s is not supported, skip it", selector.Kind, FormatPolicy("PropagationPolicy", policy))
+			continue
+		}
+		if selector.Name != "" {
+			deploy := &appsv1.Deployment{}
+			key := types.NamespacedName{Namespace: selector.Namespace, Name: selector.Name}
+			if err := client.Get(ctx, key, deploy); err != nil {
+				errs = append(errs, fmt.Errorf("failed to get deployment namespace: %s name: %s, %v", selector.Namespace, selector.Name, err))
+				continue
+			}
+			if !selected.Has(key.String()) {
+				selected.Insert(key.String())
+				deploys = append(deploys, deploy)
+			}
 			continue
 		}
-		deploys = append(deploys, deploy)
-	}
-	return deploys, apierr.NewAggregate(errs)
-}
 
-func ParseNamespaceName(namespaceName string) (string, string, error) {
-	keys := strings.Split(namespaceName, "/")
-	if len(keys) == 1 {
-		return "default", keys[0], nil
-	}
-	if len(keys) == 2 {
-		return keys[0], keys[1], nil
+		deployList := &appsv1.DeploymentList{}
+		if err := client.List(ctx, deployList, runtimeClient.InNamespace(selector.Namespace)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list deployments in namespace %s, %v", selector.Namespace, err))
+			continue
+		}
+		for i := range deployList.Items {
+			deploy := &deployList.Items[i]
+			key := types.NamespacedName{Namespace: deploy.Namespace, Name: deploy.Name}
+			if selected.Has(key.String()) || !SelectsWorkload([]policyv1alpha1.ResourceSelector{selector}, deploy) {
+				continue
+			}
+			selected.Insert(key.String())
+			deploys = append(deploys, deploy)
+		}
 	}
-	return "", "", fmt.Errorf("failed to parse NamespaceName of %s", namespaceName)
+	return deploys, apierr.NewAggregate(errs)
 }
 
 func DesiredPodsNumInTargetNodeGroups(weights []policyv1alpha1.StaticNodeGroupWeight, replicaNum int32) map[string]int32 {
@@ -105,6 +291,7 @@ func DesiredPodsNumInTargetNodeGroups(weights []policyv1alpha1.StaticNodeGroupWe
 		sum += weight.Weight
 	}
 
+	weighted := sets.NewString()
 	var allocatedPodNum int32
 	for _, weight := range weights {
 		var ratio float64
@@ -116,11 +303,14 @@ func DesiredPodsNumInTargetNodeGroups(weights []policyv1alpha1.StaticNodeGroupWe
 
 		desiredNum := int32(ratio*float64(replicaNum) + 0.5)
 		results[weight.NodeGroupNames[0]] = desiredNum
+		if weight.Weight > 0 {
+			weighted.Insert(weight.NodeGroupNames[0])
+		}
 		if len(weight.NodeGroupNames) > 1 {
 			// TODO:
 			// support multi-nodegroup one entry
 			klog.Error("multi nodegroup in one weight entry is not supported, only the first one will be picked, other nodegroup will get 0 weight.")
-			for i := 2; i < len(weight.NodeGroupNames); i++ {
+			for i := 1; i < len(weight.NodeGroupNames); i++ {
 				results[weight.NodeGroupNames[i]] = 0
 			}
 		}
@@ -130,41 +320,63 @@ func DesiredPodsNumInTargetNodeGroups(weights []policyv1alpha1.StaticNodeGroupWe
 
 	// TODO:
 	// consider how to allocate left pods when (replicaNum % sum != 0)
-	// currently add all of them to one of the nodegroups.
+	// currently add all of them to the first nodegroup in the order of names
+	// which can take them, so that the result is stable across calls.
+	// Nodegroups with zero weight never take them unless all weights are zero.
 	leftPodNum := replicaNum - allocatedPodNum
 	if leftPodNum != 0 {
+		nodegroups := make([]string, 0, len(results))
 		for nodegroup := range results {
-			results[nodegroup] += leftPodNum
-			break
+			nodegroups = append(nodegroups, nodegroup)
+		}
+		sort.Strings(nodegroups)
+		for _, nodegroup := range nodegroups {
+			if sum != 0 && !weighted.Has(nodegroup) {
+				continue
+			}
+			if results[nodegroup]+leftPodNum >= 0 {
+				results[nodegroup] += leftPodNum
+				break
+			}
 		}
 	}
 
 	return results
 }
 
-func GetPodListFromDeploy(ctx context.Context, client runtimeClient.Client, deploy *appsv1.Deployment) (*corev1.PodList, error) {
-	labelselector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
-	if err != nil {
-		return nil, err
-	}
-	podList := &corev1.PodList{}
-	if err := client.List(ctx, podList, &runtimeClient.ListOptions{LabelSelector: labelselector}); err != nil {
-		return nil, err
+// GetDesiredPodsNumOfPolicy returns the desired number of pods in each nodegroup where pods are placed
+// by the policy, see DesiredPodsNumOfPolicy.
+func GetDesiredPodsNumOfPolicy(ctx context.Context, client runtimeClient.Client, policy *policyv1alpha1.PropagationPolicy, replicaNum int32) (map[string]int32, error) {
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if policy.Spec.Placement.SplitByChildGroups {
+		if err := client.List(ctx, groupList); err != nil {
+			return nil, fmt.Errorf("failed to list nodegroups for policy %s/%s, %v", policy.Namespace, policy.Name, err)
+		}
 	}
-	return podList, nil
+	return DesiredPodsNumOfPolicy(policy, groupList.Items, replicaNum), nil
 }
 
 func CurrentPodsNumInTargetNodeGroups(ctx context.Context, client runtimeClient.Client, deploy *appsv1.Deployment, policy *policyv1alpha1.PropagationPolicy) (map[string]int32, map[string]string, error) {
-	targetNodeGroupNames := []string{}
-	for _, weight := range policy.Spec.Placement.StaticWeightList {
-		targetNodeGroupNames = append(targetNodeGroupNames, weight.NodeGroupNames...)
-	}
-
-	groups, err := GetNodeGroupsWithName(ctx, client, targetNodeGroupNames)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get nodegroups according to their names for deploy %s/%s, policy %s/%s , %v",
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := client.List(ctx, groupList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list nodegroups for deploy %s/%s, policy %s/%s , %v",
 			deploy.Namespace, deploy.Name, policy.Namespace, policy.Name, err)
 	}
+	existing := sets.NewString()
+	for i := range groupList.Items {
+		existing.Insert(groupList.Items[i].Name)
+	}
+	placement := sets.NewString(GetPlacementNodeGroupNames(policy, groupList.Items)...)
+	groups := []groupv1alpha1.NodeGroup{}
+	for _, group := range SortNodeGroupsByPolicy(groupList.Items, policy) {
+		if placement.Has(group.Name) {
+			groups = append(groups, group)
+		}
+	}
+	missing := placement.Difference(existing).List()
+	if len(missing) != 0 {
+		klog.Warningf("nodegroups %v referenced by policy %s/%s do not exist, ignore them", missing, policy.Namespace, policy.Name)
+	}
 
 	nodesInGroups, err := GetNodesInGroups(ctx, client, groups)
 	if err != nil {
@@ -185,8 +397,8 @@ func CurrentPodsNumInTargetNodeGroups(ctx context.Context, client runtimeClient.
 		}
 		group, ok := nodesInGroups[pod.Spec.NodeName]
 		if !ok {
-			// It should be solved by PropagationPolicy controller instead of the scheduler extender.
-			klog.Warningf("find pod running on the node %s which is not in target nodegroups, ignore it")
+			// It is solved by PropagationPolicy controller instead of the scheduler extender.
+			klog.Warningf("find pod %s/%s running on the node %s which is not in target nodegroups, ignore it", pod.Namespace, pod.Name, pod.Spec.NodeName)
 			continue
 		}
 		currentPodsInTargetNodeGroups[group]++
@@ -194,69 +406,72 @@ func CurrentPodsNumInTargetNodeGroups(ctx context.Context, client runtimeClient.
 	return currentPodsInTargetNodeGroups, nodesInGroups, nil
 }
 
+// GetRelativeDeployment returns the deployment controlling the pod if it is selected by the policy,
+// or nil otherwise.
 func GetRelativeDeployment(ctx context.Context, client runtimeClient.Client, pod *corev1.Pod, policy *policyv1alpha1.PropagationPolicy) (*appsv1.Deployment, error) {
-	deploys, err := GetManifestsDeploys(ctx, client, policy)
+	owner, err := GetPodOwner(ctx, client, pod)
 	if err != nil {
-		klog.Warningf("failed to get all deploys manifested by policy %s/%s, continue with fetched deploys, %v", policy.Namespace, policy.Name, err)
+		return nil, err
 	}
-
-	// get deployment relative to the pod
-	var relativeDeploy *appsv1.Deployment
-	for _, deploy := range deploys {
-		selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert LabelSelector to Selector, %v", err)
-		}
-		if selector.Matches(labels.Set(pod.Labels)) {
-			relativeDeploy = deploy
-			break
-		}
+	// only deployments are propagated by policies
+	deploy, ok := owner.(*appsv1.Deployment)
+	if !ok || !SelectsWorkload(policy.Spec.ResourceSelectors, deploy) {
+		return nil, nil
 	}
-
-	return relativeDeploy, err
+	return deploy, nil
 }
 
+// GetRelativeDeployAndPolicy returns the deployment controlling the pod and the policy propagating it,
+// which is the policy with the highest precedence among policies selecting the deployment. The policy
+// recorded in PropagationPolicyAnnotation of the deployment, or of the pod if the deployment has not
+// been recorded yet, is used if it still selects the deployment. Otherwise all policies in the
+// namespace are checked. It returns nil if the pod is not controlled by a deployment.
 func GetRelativeDeployAndPolicy(ctx context.Context, client runtimeClient.Client, pod *corev1.Pod) (*appsv1.Deployment, *policyv1alpha1.PropagationPolicy, error) {
-	// TODO:
-	// Do not fetch directly from APIServer
-	policyList := &policyv1alpha1.PropagationPolicyList{}
-	if err := client.List(ctx, policyList, &runtimeClient.ListOptions{Namespace: pod.Namespace}); err != nil {
-		return nil, nil, fmt.Errorf("failed to list policy, %v", err)
+	owner, err := GetPodOwner(ctx, client, pod)
+	if err != nil {
+		return nil, nil, err
+	}
+	deploy, ok := owner.(*appsv1.Deployment)
+	if !ok {
+		return nil, nil, nil
 	}
 
-	matchLabelsOnPod := func(deploySelector metav1.LabelSelector, podLabels map[string]string) bool {
-		selector, err := metav1.LabelSelectorAsSelector(&deploySelector)
+	policy, err := getBoundPolicy(ctx, client, pod, deploy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == nil {
+		policy, err = GetPolicyOfWorkload(ctx, client, deploy)
 		if err != nil {
-			klog.Errorf("failed to get selector from labelselector, err: %s")
-			return false
-		}
-		if selector.Matches(labels.Set(podLabels)) {
-			return true
+			return nil, nil, err
 		}
-		return false
 	}
+	if policy == nil {
+		return nil, nil, nil
+	}
+	return deploy, policy, nil
+}
 
-	for _, policy := range policyList.Items {
-		var deploys []*appsv1.Deployment
-		for _, selector := range policy.Spec.ResourceSelectors {
-			if selector.Namespace != "" && selector.Name != "" {
-				deploy := &appsv1.Deployment{}
-				if err := client.Get(ctx, runtimeClient.ObjectKey{Namespace: selector.Namespace, Name: selector.Name}, deploy); err != nil {
-					return nil, nil, fmt.Errorf("failed to get deploy with namespaceName %s/%s, %v", selector.Namespace, selector.Name, err)
-				}
-				deploys = append(deploys, deploy)
-			} else {
-				return nil, nil, fmt.Errorf("empty namespace name of resource selector in policy %s/%s", policy.Namespace, policy.Name)
-			}
-		}
-
-		for _, deploy := range deploys {
-			if matchLabelsOnPod(*deploy.Spec.Selector, pod.Labels) {
-				return deploy, &policy, nil
-			}
-		}
+// getBoundPolicy returns the policy recorded in PropagationPolicyAnnotation of the deployment, or of
+// the pod if the deployment has no such annotation. The annotation of the deployment takes precedence
+// because pods of old ReplicaSets may still carry the annotation of the previous policy during rollouts.
+// It returns nil if no policy is recorded, or the recorded policy no longer exists or selects the deployment.
+func getBoundPolicy(ctx context.Context, client runtimeClient.Client, pod *corev1.Pod, deploy *appsv1.Deployment) (*policyv1alpha1.PropagationPolicy, error) {
+	key := deploy.Annotations[policyv1alpha1.PropagationPolicyAnnotation]
+	if key == "" {
+		key = pod.Annotations[policyv1alpha1.PropagationPolicyAnnotation]
+	}
+	if key == "" {
+		return nil, nil
 	}
 
-	// find nothing
-	return nil, nil, nil
+	policy, err := GetPolicyByKey(ctx, client, key)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil || !SelectsWorkload(policy.Spec.ResourceSelectors, deploy) {
+		klog.V(2).Infof("policy %s recorded on deployment %s/%s does not propagate it any more", key, deploy.Namespace, deploy.Name)
+		return nil, nil
+	}
+	return policy, nil
 }
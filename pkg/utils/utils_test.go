@@ -3,6 +3,13 @@ package utils
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
 	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
 )
 
@@ -81,10 +88,95 @@ func TestDesiredPodsNumInTargetNodeGroups(t *testing.T) {
 				"shanghai": 10,
 			},
 		},
+		{
+			name: "left pods go to the first nodegroup by name",
+			weights: []policyv1alpha1.StaticNodeGroupWeight{
+				{
+					NodeGroupNames: []string{
+						"shanghai",
+					},
+					Weight: 1,
+				},
+				{
+					NodeGroupNames: []string{
+						"hangzhou",
+					},
+					Weight: 1,
+				},
+				{
+					NodeGroupNames: []string{
+						"beijing",
+					},
+					Weight: 1,
+				},
+			},
+			replicas: 4,
+			want: map[string]int32{
+				"beijing":  2,
+				"hangzhou": 1,
+				"shanghai": 1,
+			},
+		},
+		{
+			name: "left pods do not go to nodegroups with zero weight",
+			weights: []policyv1alpha1.StaticNodeGroupWeight{
+				{
+					NodeGroupNames: []string{
+						"hangzhou",
+						"beijing",
+					},
+					Weight: 1,
+				},
+				{
+					NodeGroupNames: []string{
+						"shanghai",
+					},
+					Weight: 1,
+				},
+				{
+					NodeGroupNames: []string{
+						"shenzhen",
+					},
+					Weight: 1,
+				},
+			},
+			replicas: 4,
+			want: map[string]int32{
+				"beijing":  0,
+				"hangzhou": 2,
+				"shanghai": 1,
+				"shenzhen": 1,
+			},
+		},
+		{
+			name: "over allocated pods are taken from the first nodegroup by name",
+			weights: []policyv1alpha1.StaticNodeGroupWeight{
+				{
+					NodeGroupNames: []string{
+						"hangzhou",
+					},
+					Weight: 1,
+				},
+				{
+					NodeGroupNames: []string{
+						"beijing",
+					},
+					Weight: 1,
+				},
+			},
+			replicas: 3,
+			want: map[string]int32{
+				"beijing":  1,
+				"hangzhou": 2,
+			},
+		},
 	}
 
 	for _, c := range cases {
 		desiredPodNum := DesiredPodsNumInTargetNodeGroups(c.weights, int32(c.replicas))
+		if len(desiredPodNum) != len(c.want) {
+			t.Errorf("case: %s, want %v but get %v", c.name, c.want, desiredPodNum)
+		}
 		for gname, num := range desiredPodNum {
 			wantnum, ok := c.want[gname]
 			if !ok {
@@ -98,3 +190,162 @@ func TestDesiredPodsNumInTargetNodeGroups(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchNodesInGroup(t *testing.T) {
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"city": "hangzhou"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"city": "beijing", "edge": ""}}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node3"},
+			Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{{Key: "edge", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		spec        groupv1alpha1.NodeGroupSpec
+		wantMembers []string
+		wantMissing []string
+	}{
+		{
+			name: "match labels only",
+			spec: groupv1alpha1.NodeGroupSpec{
+				MatchLabels: map[string]string{"city": "hangzhou"},
+			},
+			wantMembers: []string{"node1"},
+		},
+		{
+			name: "explicit nodes only",
+			spec: groupv1alpha1.NodeGroupSpec{
+				Nodes: []string{"node2", "node3"},
+			},
+			wantMembers: []string{"node2", "node3"},
+		},
+		{
+			name: "union of explicit nodes and match labels",
+			spec: groupv1alpha1.NodeGroupSpec{
+				Nodes:       []string{"node1", "node3", "node4"},
+				MatchLabels: map[string]string{"city": "beijing"},
+			},
+			wantMembers: []string{"node1", "node2", "node3"},
+			wantMissing: []string{"node4"},
+		},
+		{
+			name: "label selector with set-based requirements",
+			spec: groupv1alpha1.NodeGroupSpec{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "city", Operator: metav1.LabelSelectorOpIn, Values: []string{"hangzhou", "beijing"}},
+					},
+				},
+			},
+			wantMembers: []string{"node1", "node2"},
+		},
+		{
+			name: "match labels and label selector are ANDed",
+			spec: groupv1alpha1.NodeGroupSpec{
+				MatchLabels: map[string]string{"city": "beijing"},
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "edge", Operator: metav1.LabelSelectorOpExists},
+					},
+				},
+			},
+			wantMembers: []string{"node2"},
+		},
+		{
+			name: "match taints",
+			spec: groupv1alpha1.NodeGroupSpec{
+				MatchTaints: []groupv1alpha1.TaintSelector{{Key: "edge", Effect: corev1.TaintEffectNoSchedule}},
+			},
+			wantMembers: []string{"node3"},
+		},
+		{
+			name:        "empty spec",
+			spec:        groupv1alpha1.NodeGroupSpec{},
+			wantMembers: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		group := &groupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: "group"}, Spec: c.spec}
+		members, missing, err := MatchNodesInGroup(nodes, group)
+		if err != nil {
+			t.Errorf("case: %s, unexpected error: %v", c.name, err)
+			continue
+		}
+		memberNames := sets.NewString()
+		for _, node := range members {
+			memberNames.Insert(node.Name)
+		}
+		if !memberNames.Equal(sets.NewString(c.wantMembers...)) {
+			t.Errorf("case: %s, want members %v but get %v", c.name, c.wantMembers, memberNames.List())
+		}
+		if !sets.NewString(missing...).Equal(sets.NewString(c.wantMissing...)) {
+			t.Errorf("case: %s, want missing nodes %v but get %v", c.name, c.wantMissing, missing)
+		}
+	}
+}
+
+func TestGetPodRequests(t *testing.T) {
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name string
+		spec corev1.PodSpec
+		want corev1.ResourceList
+	}{
+		{
+			name: "sum of containers",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("100m", "100Mi"), container("200m", "50Mi")},
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("300m"),
+				corev1.ResourceMemory: resource.MustParse("150Mi"),
+			},
+		},
+		{
+			name: "init container requests more",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("1", "10Mi")},
+				Containers:     []corev1.Container{container("100m", "100Mi")},
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("100Mi"),
+			},
+		},
+		{
+			name: "with overhead",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("100m", "100Mi")},
+				Overhead: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("50m"),
+				},
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("150m"),
+				corev1.ResourceMemory: resource.MustParse("100Mi"),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		requests := GetPodRequests(&corev1.Pod{Spec: c.spec})
+		if !equality.Semantic.DeepEqual(requests, c.want) {
+			t.Errorf("case: %s, want %v but get %v", c.name, c.want, requests)
+		}
+	}
+}
@@ -0,0 +1,369 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+)
+
+// ValidatePropagationPolicy validates the spec of the PropagationPolicy.
+func ValidatePropagationPolicy(policy *policyv1alpha1.PropagationPolicy) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	if len(policy.Spec.ResourceSelectors) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("resourceSelectors"), "at least one resource selector must be specified"))
+	}
+	allErrs = append(allErrs, ValidateResourceSelectors(policy.Spec.ResourceSelectors, policy.Namespace, specPath.Child("resourceSelectors"))...)
+	allErrs = append(allErrs, validatePropagatedKinds(policy.Spec.ResourceSelectors, specPath.Child("resourceSelectors"))...)
+	allErrs = append(allErrs, ValidateStaticWeightList(policy.Spec.Placement.StaticWeightList, specPath.Child("placement", "staticWeightList"))...)
+	allErrs = append(allErrs, validateRebalanceStrategy(&policy.Spec.Rebalance, specPath.Child("rebalance"))...)
+	allErrs = append(allErrs, validateFailoverPolicy(&policy.Spec.Failover, specPath.Child("failover"))...)
+	return allErrs
+}
+
+// ValidateResourceSelectors validates that each of the resource selectors has a parsable apiVersion,
+// a kind, a valid label selector and a namespace same as the policy. Resource selectors of
+// cluster-scoped policies, whose namespace is empty, must specify the namespace.
+func ValidateResourceSelectors(selectors []policyv1alpha1.ResourceSelector, namespace string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, selector := range selectors {
+		idxPath := fldPath.Index(i)
+		if selector.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("apiVersion"), ""))
+		} else if _, err := schema.ParseGroupVersion(selector.APIVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("apiVersion"), selector.APIVersion, err.Error()))
+		}
+		if selector.Kind == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kind"), ""))
+		}
+		if namespace == "" && selector.Namespace == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("namespace"), "namespace is required by cluster-scoped policies"))
+		} else if namespace != "" && selector.Namespace != "" && selector.Namespace != namespace {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("namespace"), selector.Namespace,
+				fmt.Sprintf("must be empty or the same as the namespace of the policy %q", namespace)))
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(selector.LabelSelector, idxPath.Child("labelSelector"))...)
+	}
+	return allErrs
+}
+
+// validatePropagatedKinds validates that resource selectors of propagation policies select deployments,
+// which are the only kind of workloads propagated by the controller.
+func validatePropagatedKinds(selectors []policyv1alpha1.ResourceSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, selector := range selectors {
+		idxPath := fldPath.Index(i)
+		if selector.APIVersion != "" && selector.APIVersion != "apps/v1" {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("apiVersion"), selector.APIVersion, []string{"apps/v1"}))
+		}
+		if selector.Kind != "" && selector.Kind != "Deployment" {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("kind"), selector.Kind, []string{"Deployment"}))
+		}
+	}
+	return allErrs
+}
+
+// ValidateStaticWeightList validates that the weight list is not empty, each entry of it has
+// nodegroups and a positive weight, and no nodegroup is listed more than once.
+func ValidateStaticWeightList(weights []policyv1alpha1.StaticNodeGroupWeight, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(weights) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one nodegroup weight must be specified"))
+	}
+	seen := sets.NewString()
+	for i, weight := range weights {
+		idxPath := fldPath.Index(i)
+		if len(weight.NodeGroupNames) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("nodeGroupNames"), "at least one nodegroup must be specified"))
+		}
+		for j, name := range weight.NodeGroupNames {
+			namePath := idxPath.Child("nodeGroupNames").Index(j)
+			if name == "" {
+				allErrs = append(allErrs, field.Required(namePath, ""))
+				continue
+			}
+			if seen.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(namePath, name))
+			}
+			seen.Insert(name)
+		}
+		if weight.Weight < 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), weight.Weight, "must be greater than or equal to 1"))
+		}
+	}
+	return allErrs
+}
+
+func validateRebalanceStrategy(strategy *policyv1alpha1.RebalanceStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch strategy.Type {
+	case "", policyv1alpha1.EvictRebalanceStrategy, policyv1alpha1.DeleteRebalanceStrategy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), strategy.Type,
+			[]string{string(policyv1alpha1.EvictRebalanceStrategy), string(policyv1alpha1.DeleteRebalanceStrategy)}))
+	}
+	if strategy.MaxUnavailable != nil {
+		maxUnavailablePath := fldPath.Child("maxUnavailable")
+		value, err := intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(maxUnavailablePath, strategy.MaxUnavailable.String(), err.Error()))
+		} else if value < 0 {
+			allErrs = append(allErrs, field.Invalid(maxUnavailablePath, strategy.MaxUnavailable.String(), "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateFailoverPolicy(failover *policyv1alpha1.FailoverPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if failover.TolerationSeconds != nil && *failover.TolerationSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tolerationSeconds"), *failover.TolerationSeconds, "must be greater than or equal to 0"))
+	}
+	if failover.RecoverySeconds != nil && *failover.RecoverySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("recoverySeconds"), *failover.RecoverySeconds, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// ValidateOverridePolicy validates the spec of the OverridePolicy.
+func ValidateOverridePolicy(policy *policyv1alpha1.OverridePolicy) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := ValidateResourceSelectors(policy.Spec.ResourceSelectors, policy.Namespace, specPath.Child("resourceSelectors"))
+	for i, rule := range policy.Spec.OverrideRules {
+		rulePath := specPath.Child("overrideRules").Index(i)
+		if len(rule.TargetNodeGroup) > 1 && sets.NewString(rule.TargetNodeGroup...).Has(policyv1alpha1.AllNodeGroups) {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("targetNodeGroup"), rule.TargetNodeGroup,
+				fmt.Sprintf("%q cannot be used with other nodegroups", policyv1alpha1.AllNodeGroups)))
+		}
+		allErrs = append(allErrs, validateOverriders(&rule.Overriders, rulePath.Child("overriders"))...)
+	}
+	return allErrs
+}
+
+func validateOverriders(overriders *policyv1alpha1.Overriders, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, overrider := range overriders.Plaintext {
+		allErrs = append(allErrs, validatePlaintextOverrider(&overrider, fldPath.Child("plaintext").Index(i))...)
+	}
+	for i, overrider := range overriders.ImageOverrider {
+		allErrs = append(allErrs, validateImageOverrider(&overrider, fldPath.Child("imageOverrider").Index(i))...)
+	}
+	for i, overrider := range overriders.CommandOverrider {
+		allErrs = append(allErrs, validateCommandArgsOverrider(&overrider, fldPath.Child("commandOverrider").Index(i))...)
+	}
+	for i, overrider := range overriders.ArgsOverrider {
+		allErrs = append(allErrs, validateCommandArgsOverrider(&overrider, fldPath.Child("argsOverrider").Index(i))...)
+	}
+	return allErrs
+}
+
+func validatePlaintextOverrider(overrider *policyv1alpha1.PlaintextOverrider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := ValidateJSONPointer(overrider.Path); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), overrider.Path, err.Error()))
+	}
+	hasValue := len(overrider.Value.Raw) != 0
+	switch overrider.Operator {
+	case policyv1alpha1.OverriderOpAdd, policyv1alpha1.OverriderOpReplace:
+		if !hasValue {
+			allErrs = append(allErrs, field.Required(fldPath.Child("value"),
+				fmt.Sprintf("value is required for operator %q", overrider.Operator)))
+		}
+	case policyv1alpha1.OverriderOpRemove:
+		if hasValue {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("value"), "value is not allowed for operator \"remove\""))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), overrider.Operator,
+			[]string{string(policyv1alpha1.OverriderOpAdd), string(policyv1alpha1.OverriderOpRemove), string(policyv1alpha1.OverriderOpReplace)}))
+	}
+	return allErrs
+}
+
+// ValidateJSONPointer validates that the path is a JSON pointer as defined in RFC 6901
+// referencing a field rather than the whole document.
+func ValidateJSONPointer(path string) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("must start with \"/\"")
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '~' {
+			continue
+		}
+		if i+1 == len(path) || (path[i+1] != '0' && path[i+1] != '1') {
+			return fmt.Errorf("\"~\" must be escaped as \"~0\", and \"/\" in a reference token as \"~1\"")
+		}
+	}
+	return nil
+}
+
+func validateImageOverrider(overrider *policyv1alpha1.ImageOverrider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if overrider.Predicate != nil {
+		if err := ValidateJSONPointer(overrider.Predicate.Path); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("predicate", "path"), overrider.Predicate.Path, err.Error()))
+		}
+	}
+	switch overrider.Component {
+	case policyv1alpha1.Registry, policyv1alpha1.Tag:
+	case policyv1alpha1.Repository:
+		if overrider.Operator == policyv1alpha1.OverriderOpRemove {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("operator"), "repository of an image cannot be removed"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("component"), overrider.Component,
+			[]string{string(policyv1alpha1.Registry), string(policyv1alpha1.Repository), string(policyv1alpha1.Tag)}))
+	}
+	switch overrider.Operator {
+	case policyv1alpha1.OverriderOpAdd, policyv1alpha1.OverriderOpReplace:
+		if overrider.Value == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("value"),
+				fmt.Sprintf("value is required for operator %q", overrider.Operator)))
+		}
+	case policyv1alpha1.OverriderOpRemove:
+		if overrider.Value != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("value"), "value is not allowed for operator \"remove\""))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), overrider.Operator,
+			[]string{string(policyv1alpha1.OverriderOpAdd), string(policyv1alpha1.OverriderOpRemove), string(policyv1alpha1.OverriderOpReplace)}))
+	}
+	return allErrs
+}
+
+func validateCommandArgsOverrider(overrider *policyv1alpha1.CommandArgsOverrider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if overrider.ContainerName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("containerName"), ""))
+	}
+	switch overrider.Operator {
+	case policyv1alpha1.OverriderOpAdd, policyv1alpha1.OverriderOpRemove:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), overrider.Operator,
+			[]string{string(policyv1alpha1.OverriderOpAdd), string(policyv1alpha1.OverriderOpRemove)}))
+	}
+	return allErrs
+}
+
+// ValidateNodeGroup validates the spec of the NodeGroup.
+func ValidateNodeGroup(group *groupv1alpha1.NodeGroup) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := metav1validation.ValidateLabelSelector(group.Spec.LabelSelector, specPath.Child("labelSelector"))
+	allErrs = append(allErrs, metav1validation.ValidateLabels(group.Spec.MatchLabels, specPath.Child("matchLabels"))...)
+	for i, taint := range group.Spec.MatchTaints {
+		if taint.Key == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("matchTaints").Index(i).Child("key"), ""))
+		}
+	}
+
+	childrenPath := specPath.Child("childGroups")
+	seen := sets.NewString()
+	for i, child := range group.Spec.ChildGroups {
+		namePath := childrenPath.Index(i).Child("name")
+		switch {
+		case child.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		case child.Name == group.Name:
+			allErrs = append(allErrs, field.Invalid(namePath, child.Name, "a nodegroup cannot be a child of itself"))
+		case seen.Has(child.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, child.Name))
+		}
+		seen.Insert(child.Name)
+		if child.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(childrenPath.Index(i).Child("weight"), child.Weight, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateChildGroupsAcyclic validates that the child nodegroups of the nodegroup do not form a
+// cycle with the existing nodegroups, in which the nodegroup replaces its old version.
+func ValidateChildGroupsAcyclic(group *groupv1alpha1.NodeGroup, groups []groupv1alpha1.NodeGroup) field.ErrorList {
+	groupMap := make(map[string]*groupv1alpha1.NodeGroup, len(groups)+1)
+	for i := range groups {
+		groupMap[groups[i].Name] = &groups[i]
+	}
+	groupMap[group.Name] = group
+
+	visited := sets.NewString()
+	var reaches func(name string) bool
+	reaches = func(name string) bool {
+		if name == group.Name {
+			return true
+		}
+		if visited.Has(name) {
+			return false
+		}
+		visited.Insert(name)
+		if child, ok := groupMap[name]; ok {
+			for _, grandchild := range child.Spec.ChildGroups {
+				if reaches(grandchild.Name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	allErrs := field.ErrorList{}
+	childrenPath := field.NewPath("spec", "childGroups")
+	for i, child := range group.Spec.ChildGroups {
+		if child.Name == group.Name {
+			// reported by ValidateNodeGroup
+			continue
+		}
+		if reaches(child.Name) {
+			allErrs = append(allErrs, field.Invalid(childrenPath.Index(i).Child("name"), child.Name,
+				fmt.Sprintf("nodegroup %s is an ancestor of nodegroup %s", child.Name, group.Name)))
+		}
+	}
+	return allErrs
+}
+
+// ValidateResourceSelectorsResolvable validates that kinds of resource selectors are served
+// by the cluster. Selectors with an invalid apiVersion or kind are reported by ValidateResourceSelectors.
+func ValidateResourceSelectorsResolvable(mapper meta.RESTMapper, selectors []policyv1alpha1.ResourceSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, selector := range selectors {
+		gv, err := schema.ParseGroupVersion(selector.APIVersion)
+		if err != nil || selector.Kind == "" {
+			continue
+		}
+		if _, err := mapper.RESTMapping(gv.WithKind(selector.Kind).GroupKind(), gv.Version); err != nil {
+			kindPath := fldPath.Index(i).Child("kind")
+			if meta.IsNoMatchError(err) {
+				allErrs = append(allErrs, field.Invalid(kindPath, selector.Kind,
+					fmt.Sprintf("no kind %s is served in %s", selector.Kind, selector.APIVersion)))
+				continue
+			}
+			allErrs = append(allErrs, field.InternalError(kindPath, err))
+		}
+	}
+	return allErrs
+}
+
+// GetUnknownNodeGroupWarnings returns a warning for each of the referenced nodegroups which
+// does not exist. Unknown nodegroups are allowed, because they can be created later.
+func GetUnknownNodeGroupWarnings(ctx context.Context, client runtimeClient.Client, names []string) ([]string, error) {
+	_, missing, err := utils.GetNodeGroupsWithName(ctx, client, sets.NewString(names...).List())
+	if err != nil {
+		return nil, err
+	}
+	warnings := make([]string, 0, len(missing))
+	for _, name := range missing {
+		warnings = append(warnings, fmt.Sprintf("nodegroup %s does not exist", name))
+	}
+	return warnings, nil
+}
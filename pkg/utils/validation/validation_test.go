@@ -0,0 +1,369 @@
+package validation
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func TestValidatePropagationPolicy(t *testing.T) {
+	newPolicy := func(mutate func(spec *policyv1alpha1.PropagationPolicySpec)) *policyv1alpha1.PropagationPolicy {
+		policy := &policyv1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+			Spec: policyv1alpha1.PropagationPolicySpec{
+				ResourceSelectors: []policyv1alpha1.ResourceSelector{
+					{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "nginx"},
+				},
+				Placement: policyv1alpha1.NodeGroupPreferences{
+					StaticWeightList: []policyv1alpha1.StaticNodeGroupWeight{
+						{NodeGroupNames: []string{"hangzhou"}, Weight: 1},
+						{NodeGroupNames: []string{"beijing"}, Weight: 2},
+					},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(&policy.Spec)
+		}
+		return policy
+	}
+	maxUnavailable := intstr.FromString("25")
+
+	cases := []struct {
+		name    string
+		policy  *policyv1alpha1.PropagationPolicy
+		wantErr bool
+	}{
+		{
+			name:   "valid policy",
+			policy: newPolicy(nil),
+		},
+		{
+			name: "no resource selector",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors = nil
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid apiVersion",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].APIVersion = "apps/v1/beta"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unsupported kind",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].Kind = "StatefulSet"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unsupported apiVersion",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].APIVersion = "extensions/v1beta1"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "deployments selected by labels",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].Name = ""
+				spec.ResourceSelectors[0].LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+			}),
+		},
+		{
+			name: "all deployments in the namespace",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].Name = ""
+			}),
+		},
+		{
+			name: "resource selector in another namespace",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.ResourceSelectors[0].Namespace = "kube-system"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "cluster-scoped policy selects resource without namespace",
+			policy: func() *policyv1alpha1.PropagationPolicy {
+				policy := newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+					spec.ResourceSelectors[0].Namespace = ""
+				})
+				policy.Namespace = ""
+				return policy
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "no weight",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.Placement.StaticWeightList = nil
+			}),
+			wantErr: true,
+		},
+		{
+			name: "empty nodegroup names",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.Placement.StaticWeightList[0].NodeGroupNames = nil
+			}),
+			wantErr: true,
+		},
+		{
+			name: "zero weight",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.Placement.StaticWeightList[0].Weight = 0
+			}),
+			wantErr: true,
+		},
+		{
+			name: "duplicate nodegroup",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.Placement.StaticWeightList[1].NodeGroupNames = []string{"hangzhou"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "maxUnavailable is not a percentage",
+			policy: newPolicy(func(spec *policyv1alpha1.PropagationPolicySpec) {
+				spec.Rebalance.MaxUnavailable = &maxUnavailable
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		errs := ValidatePropagationPolicy(c.policy)
+		if c.wantErr && len(errs) == 0 {
+			t.Errorf("case: %s, want error but get nil", c.name)
+		}
+		if !c.wantErr && len(errs) != 0 {
+			t.Errorf("case: %s, unexpected error: %v", c.name, errs.ToAggregate())
+		}
+	}
+}
+
+func TestValidateOverridePolicy(t *testing.T) {
+	newPolicy := func(targets []string, overriders policyv1alpha1.Overriders) *policyv1alpha1.OverridePolicy {
+		if targets == nil {
+			targets = []string{"hangzhou"}
+		}
+		return &policyv1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+			Spec: policyv1alpha1.OverrideSpec{
+				ResourceSelectors: []policyv1alpha1.ResourceSelector{
+					{APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx"},
+				},
+				OverrideRules: []policyv1alpha1.RuleWithNodeGroup{
+					{TargetNodeGroup: targets, Overriders: overriders},
+				},
+			},
+		}
+	}
+	value := apiextensionsv1.JSON{Raw: []byte(`"nginx"`)}
+
+	cases := []struct {
+		name       string
+		targets    []string
+		overriders policyv1alpha1.Overriders
+		wantErr    bool
+	}{
+		{
+			name: "valid overriders",
+			overriders: policyv1alpha1.Overriders{
+				Plaintext: []policyv1alpha1.PlaintextOverrider{
+					{Path: "/metadata/annotations/foo~1bar", Operator: policyv1alpha1.OverriderOpAdd, Value: value},
+					{Path: "/spec/replicas", Operator: policyv1alpha1.OverriderOpRemove},
+				},
+				ImageOverrider: []policyv1alpha1.ImageOverrider{
+					{Component: policyv1alpha1.Registry, Operator: policyv1alpha1.OverriderOpReplace, Value: "registry.cn-hangzhou.aliyuncs.com"},
+					{Component: policyv1alpha1.Tag, Operator: policyv1alpha1.OverriderOpRemove},
+				},
+				CommandOverrider: []policyv1alpha1.CommandArgsOverrider{
+					{ContainerName: "nginx", Operator: policyv1alpha1.OverriderOpAdd, Value: []string{"--debug"}},
+				},
+			},
+		},
+		{
+			name: "path is not a JSON pointer",
+			overriders: policyv1alpha1.Overriders{
+				Plaintext: []policyv1alpha1.PlaintextOverrider{
+					{Path: "spec.replicas", Operator: policyv1alpha1.OverriderOpReplace, Value: value},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "path with invalid escape",
+			overriders: policyv1alpha1.Overriders{
+				Plaintext: []policyv1alpha1.PlaintextOverrider{
+					{Path: "/metadata/annotations/foo~2bar", Operator: policyv1alpha1.OverriderOpReplace, Value: value},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "replace without value",
+			overriders: policyv1alpha1.Overriders{
+				Plaintext: []policyv1alpha1.PlaintextOverrider{
+					{Path: "/spec/replicas", Operator: policyv1alpha1.OverriderOpReplace},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "remove with value",
+			overriders: policyv1alpha1.Overriders{
+				Plaintext: []policyv1alpha1.PlaintextOverrider{
+					{Path: "/spec/replicas", Operator: policyv1alpha1.OverriderOpRemove, Value: value},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "remove repository of image",
+			overriders: policyv1alpha1.Overriders{
+				ImageOverrider: []policyv1alpha1.ImageOverrider{
+					{Component: policyv1alpha1.Repository, Operator: policyv1alpha1.OverriderOpRemove},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown image component",
+			overriders: policyv1alpha1.Overriders{
+				ImageOverrider: []policyv1alpha1.ImageOverrider{
+					{Component: "Digest", Operator: policyv1alpha1.OverriderOpReplace, Value: "sha256:0"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "all nodegroups with other nodegroups",
+			targets:    []string{policyv1alpha1.AllNodeGroups, "hangzhou"},
+			overriders: policyv1alpha1.Overriders{},
+			wantErr:    true,
+		},
+		{
+			name: "replace args",
+			overriders: policyv1alpha1.Overriders{
+				ArgsOverrider: []policyv1alpha1.CommandArgsOverrider{
+					{ContainerName: "nginx", Operator: policyv1alpha1.OverriderOpReplace},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		errs := ValidateOverridePolicy(newPolicy(c.targets, c.overriders))
+		if c.wantErr && len(errs) == 0 {
+			t.Errorf("case: %s, want error but get nil", c.name)
+		}
+		if !c.wantErr && len(errs) != 0 {
+			t.Errorf("case: %s, unexpected error: %v", c.name, errs.ToAggregate())
+		}
+	}
+}
+
+func TestValidateNodeGroup(t *testing.T) {
+	newGroup := func(children ...string) *groupv1alpha1.NodeGroup {
+		group := &groupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: "china"}}
+		for _, child := range children {
+			group.Spec.ChildGroups = append(group.Spec.ChildGroups, groupv1alpha1.ChildNodeGroup{Name: child, Weight: 1})
+		}
+		return group
+	}
+	invalidSelector := newGroup()
+	invalidSelector.Spec.LabelSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "region", Operator: metav1.LabelSelectorOpIn}},
+	}
+
+	cases := []struct {
+		name    string
+		group   *groupv1alpha1.NodeGroup
+		wantErr bool
+	}{
+		{
+			name:  "valid nodegroup",
+			group: newGroup("zhejiang", "beijing"),
+		},
+		{
+			name:    "child of itself",
+			group:   newGroup("china"),
+			wantErr: true,
+		},
+		{
+			name:    "duplicate child",
+			group:   newGroup("zhejiang", "zhejiang"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid label selector",
+			group:   invalidSelector,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		errs := ValidateNodeGroup(c.group)
+		if c.wantErr && len(errs) == 0 {
+			t.Errorf("case: %s, want error but get nil", c.name)
+		}
+		if !c.wantErr && len(errs) != 0 {
+			t.Errorf("case: %s, unexpected error: %v", c.name, errs.ToAggregate())
+		}
+	}
+}
+
+func TestValidateChildGroupsAcyclic(t *testing.T) {
+	newGroup := func(name string, children ...string) groupv1alpha1.NodeGroup {
+		group := groupv1alpha1.NodeGroup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, child := range children {
+			group.Spec.ChildGroups = append(group.Spec.ChildGroups, groupv1alpha1.ChildNodeGroup{Name: child, Weight: 1})
+		}
+		return group
+	}
+	groups := []groupv1alpha1.NodeGroup{
+		newGroup("china", "zhejiang"),
+		newGroup("zhejiang", "hangzhou"),
+		newGroup("hangzhou"),
+	}
+
+	cases := []struct {
+		name    string
+		group   groupv1alpha1.NodeGroup
+		wantErr bool
+	}{
+		{
+			name:  "new child nodegroup",
+			group: newGroup("hangzhou", "xihu"),
+		},
+		{
+			name:  "nodegroup replaces its old version",
+			group: newGroup("zhejiang", "ningbo"),
+		},
+		{
+			name:    "ancestor as child nodegroup",
+			group:   newGroup("hangzhou", "china"),
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		errs := ValidateChildGroupsAcyclic(&c.group, groups)
+		if c.wantErr && len(errs) == 0 {
+			t.Errorf("case: %s, want error but get nil", c.name)
+		}
+		if !c.wantErr && len(errs) != 0 {
+			t.Errorf("case: %s, unexpected error: %v", c.name, errs.ToAggregate())
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetPodOwner returns the workload controlling the pod through ownerReferences, which is the
+// Deployment controlling its ReplicaSet, or the ReplicaSet if it is not controlled by a Deployment,
+// the StatefulSet or the Job. Pods of old ReplicaSets during rollouts are resolved to the same
+// Deployment. It returns nil if the pod has no controller of these kinds, or the controller
+// no longer exists.
+func GetPodOwner(ctx context.Context, client runtimeClient.Client, pod *corev1.Pod) (runtimeClient.Object, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil, nil
+	}
+
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs := &appsv1.ReplicaSet{}
+		if found, err := getController(ctx, client, pod.Namespace, ref, rs); err != nil || !found {
+			return nil, err
+		}
+		deployRef := metav1.GetControllerOf(rs)
+		if deployRef == nil || deployRef.Kind != "Deployment" {
+			return rs, nil
+		}
+		deploy := &appsv1.Deployment{}
+		if found, err := getController(ctx, client, pod.Namespace, deployRef, deploy); err != nil || !found {
+			return nil, err
+		}
+		return deploy, nil
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if found, err := getController(ctx, client, pod.Namespace, ref, sts); err != nil || !found {
+			return nil, err
+		}
+		return sts, nil
+	case "Job":
+		job := &batchv1.Job{}
+		if found, err := getController(ctx, client, pod.Namespace, ref, job); err != nil || !found {
+			return nil, err
+		}
+		return job, nil
+	}
+	return nil, nil
+}
+
+// getController gets the controller referred by the ownerReference into obj. It returns false
+// if the controller does not exist or has been recreated with another UID.
+func getController(ctx context.Context, client runtimeClient.Client, namespace string, ref *metav1.OwnerReference, obj runtimeClient.Object) (bool, error) {
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s %s/%s, %v", ref.Kind, namespace, ref.Name, err)
+	}
+	return obj.GetUID() == ref.UID, nil
+}
+
+// GetPodListFromDeploy returns pods controlled by ReplicaSets of the deployment, including
+// ReplicaSets of old revisions during rollouts. Pods matching the selector of the deployment
+// but controlled by other workloads are excluded.
+func GetPodListFromDeploy(ctx context.Context, client runtimeClient.Client, deploy *appsv1.Deployment) (*corev1.PodList, error) {
+	labelselector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	listOptions := &runtimeClient.ListOptions{Namespace: deploy.Namespace, LabelSelector: labelselector}
+
+	rsList := &appsv1.ReplicaSetList{}
+	if err := client.List(ctx, rsList, listOptions); err != nil {
+		return nil, err
+	}
+	replicaSets := sets.NewString()
+	for i := range rsList.Items {
+		if ref := metav1.GetControllerOf(&rsList.Items[i]); ref != nil && ref.UID == deploy.UID {
+			replicaSets.Insert(string(rsList.Items[i].UID))
+		}
+	}
+
+	podList := &corev1.PodList{}
+	if err := client.List(ctx, podList, listOptions); err != nil {
+		return nil, err
+	}
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		if ref := metav1.GetControllerOf(&podList.Items[i]); ref != nil && replicaSets.Has(string(ref.UID)) {
+			pods = append(pods, podList.Items[i])
+		}
+	}
+	podList.Items = pods
+	return podList, nil
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+func newFakeClient(t *testing.T, objs ...runtimeClient.Object) runtimeClient.Client {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := policyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newOwnedObjectMeta(namespace, name string, owner runtimeClient.Object, kind string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		UID:       types.UID(name),
+		Labels:    map[string]string{"app": "web"},
+	}
+	if owner != nil {
+		gvk := appsv1.SchemeGroupVersion.WithKind(kind)
+		if kind == "Job" {
+			gvk = batchv1.SchemeGroupVersion.WithKind(kind)
+		}
+		meta.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, gvk)}
+	}
+	return meta
+}
+
+func TestGetPodOwner(t *testing.T) {
+	deploy := &appsv1.Deployment{ObjectMeta: newOwnedObjectMeta("default", "deploy", nil, "")}
+	deployRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "deploy-rs", deploy, "Deployment")}
+	bareRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "bare-rs", nil, "")}
+	sts := &appsv1.StatefulSet{ObjectMeta: newOwnedObjectMeta("default", "sts", nil, "")}
+	job := &batchv1.Job{ObjectMeta: newOwnedObjectMeta("default", "job", nil, "")}
+	recreated := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "recreated-rs", nil, "")}
+	client := newFakeClient(t, deploy, deployRS, bareRS, sts, job, recreated)
+
+	staleRS := recreated.DeepCopy()
+	staleRS.UID = "stale"
+	cases := []struct {
+		name string
+		pod  *corev1.Pod
+		want string
+	}{
+		{
+			name: "pod of deployment",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", deployRS, "ReplicaSet")},
+			want: "deploy",
+		},
+		{
+			name: "pod of replicaset without deployment",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", bareRS, "ReplicaSet")},
+			want: "bare-rs",
+		},
+		{
+			name: "pod of statefulset",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", sts, "StatefulSet")},
+			want: "sts",
+		},
+		{
+			name: "pod of job",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", job, "Job")},
+			want: "job",
+		},
+		{
+			name: "pod of recreated owner",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", staleRS, "ReplicaSet")},
+		},
+		{
+			name: "pod without owner",
+			pod:  &corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "pod", nil, "")},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			owner, err := GetPodOwner(context.TODO(), client, c.pod)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			got := ""
+			if owner != nil {
+				got = owner.GetName()
+			}
+			if got != c.want {
+				t.Errorf("want owner %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestGetPodListFromDeploy(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	deploy := &appsv1.Deployment{ObjectMeta: newOwnedObjectMeta("default", "deploy", nil, "")}
+	deploy.Spec.Selector = selector
+	// another deployment with an overlapping selector
+	other := &appsv1.Deployment{ObjectMeta: newOwnedObjectMeta("default", "other", nil, "")}
+	other.Spec.Selector = selector
+
+	newRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "deploy-new", deploy, "Deployment")}
+	oldRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "deploy-old", deploy, "Deployment")}
+	otherRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("default", "other-rs", other, "Deployment")}
+	// a replicaset in another namespace with the same name as the new one
+	foreignRS := &appsv1.ReplicaSet{ObjectMeta: newOwnedObjectMeta("foreign", "deploy-new", nil, "")}
+	foreignRS.UID = newRS.UID
+
+	client := newFakeClient(t, deploy, other, newRS, oldRS, otherRS, foreignRS,
+		&corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "new-pod", newRS, "ReplicaSet")},
+		&corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "old-pod", oldRS, "ReplicaSet")},
+		&corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "other-pod", otherRS, "ReplicaSet")},
+		&corev1.Pod{ObjectMeta: newOwnedObjectMeta("default", "bare-pod", nil, "")},
+		&corev1.Pod{ObjectMeta: newOwnedObjectMeta("foreign", "foreign-pod", foreignRS, "ReplicaSet")},
+	)
+
+	podList, err := GetPodListFromDeploy(context.TODO(), client, deploy)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	got := sets.NewString()
+	for _, pod := range podList.Items {
+		got.Insert(pod.Namespace + "/" + pod.Name)
+	}
+	if want := sets.NewString("default/new-pod", "default/old-pod"); !got.Equal(want) {
+		t.Errorf("want pods %v, got %v", want.List(), got.List())
+	}
+}
@@ -0,0 +1,102 @@
+package nodegroup
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	groupv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/group/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+	"github.com/Congrool/nodes-grouping/pkg/utils/validation"
+)
+
+// +kubebuilder:webhook:path=/validate-nodegroup,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1,groups=group.kubeedge.io,resources=nodegroups,verbs=create;update,versions=v1alpha1,name=validate-nodegroup.group.kubeedge.io
+
+// ValidatingAdmission validates NodeGroup objects when creating or updating them.
+type ValidatingAdmission struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ValidatingAdmission{}
+var _ admission.DecoderInjector = &ValidatingAdmission{}
+
+// Handle denies the nodegroup if it is invalid, overlaps with other exclusive nodegroups or
+// nests its ancestors, and warns about child nodegroups which do not exist. Updates which
+// do not change the spec, or of nodegroups being deleted, are always allowed.
+func (v *ValidatingAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	group := &groupv1alpha1.NodeGroup{}
+	if err := v.decoder.Decode(req, group); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	klog.V(2).Infof("validating nodegroup %s for request: %s", req.Name, req.Operation)
+
+	var oldGroup *groupv1alpha1.NodeGroup
+	if req.Operation == admissionv1.Update {
+		oldGroup = &groupv1alpha1.NodeGroup{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldGroup); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// updates of metadata or status, such as finalizers added or removed by the controller,
+		// must not be blocked by nodes relabeled after the nodegroup is admitted
+		if group.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldGroup.Spec, group.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if allErrs := validation.ValidateNodeGroup(group); len(allErrs) != 0 {
+		return admission.Denied(allErrs.ToAggregate().Error())
+	}
+
+	groupList := &groupv1alpha1.NodeGroupList{}
+	if err := v.Client.List(ctx, groupList); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if allErrs := validation.ValidateChildGroupsAcyclic(group, groupList.Items); len(allErrs) != 0 {
+		return admission.Denied(allErrs.ToAggregate().Error())
+	}
+	// exclusive nodegroups may come to overlap when nodes are relabeled, which is reported by the
+	// controller, so only check the overlap when the nodegroup changes its members
+	if group.Spec.Exclusive && (oldGroup == nil || membershipChanged(oldGroup, group)) {
+		nodeList := &corev1.NodeList{}
+		if err := v.Client.List(ctx, nodeList); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if err := utils.ValidateExclusiveNodeGroup(nodeList.Items, group, groupList.Items); err != nil {
+			return admission.Denied(err.Error())
+		}
+	}
+
+	names := make([]string, 0, len(group.Spec.ChildGroups))
+	for _, child := range group.Spec.ChildGroups {
+		names = append(names, child.Name)
+	}
+	warnings, err := validation.GetUnknownNodeGroupWarnings(ctx, v.Client, names)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// membershipChanged returns true if the update changes which nodes belong to the nodegroup
+// or makes it exclusive.
+func membershipChanged(oldGroup, group *groupv1alpha1.NodeGroup) bool {
+	return oldGroup.Spec.Exclusive != group.Spec.Exclusive ||
+		!equality.Semantic.DeepEqual(oldGroup.Spec.Nodes, group.Spec.Nodes) ||
+		!equality.Semantic.DeepEqual(oldGroup.Spec.MatchLabels, group.Spec.MatchLabels) ||
+		!equality.Semantic.DeepEqual(oldGroup.Spec.LabelSelector, group.Spec.LabelSelector) ||
+		!equality.Semantic.DeepEqual(oldGroup.Spec.MatchTaints, group.Spec.MatchTaints)
+}
+
+// InjectDecoder implements admission.DecoderInjector interface.
+// A decoder will be automatically injected.
+func (v *ValidatingAdmission) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
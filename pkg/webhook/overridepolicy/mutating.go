@@ -0,0 +1,58 @@
+package overridepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-overridepolicy,mutating=true,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1,groups=policy.kubeedge.io,resources=overridepolicies;clusteroverridepolicies,verbs=create;update,versions=v1alpha1,name=mutate-overridepolicy.policy.kubeedge.io
+
+// MutatingAdmission sets defaults of OverridePolicy and ClusterOverridePolicy objects when creating or updating them.
+type MutatingAdmission struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &MutatingAdmission{}
+var _ admission.DecoderInjector = &MutatingAdmission{}
+
+// Handle patches the policy with its defaults.
+func (m *MutatingAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	klog.V(2).Infof("mutating %s %s for request: %s", req.Kind.Kind, formatRequestObject(req), req.Operation)
+
+	var policy interface{}
+	switch req.Kind.Kind {
+	case "ClusterOverridePolicy":
+		clusterPolicy := &policyv1alpha1.ClusterOverridePolicy{}
+		if err := m.decoder.Decode(req, clusterPolicy); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		policyv1alpha1.SetDefaultsClusterOverridePolicy(clusterPolicy)
+		policy = clusterPolicy
+	default:
+		namespacedPolicy := &policyv1alpha1.OverridePolicy{}
+		if err := m.decoder.Decode(req, namespacedPolicy); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		policyv1alpha1.SetDefaultsOverridePolicy(namespacedPolicy)
+		policy = namespacedPolicy
+	}
+
+	marshaledBytes, err := json.Marshal(policy)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledBytes)
+}
+
+// InjectDecoder implements admission.DecoderInjector interface.
+// A decoder will be automatically injected.
+func (m *MutatingAdmission) InjectDecoder(d *admission.Decoder) error {
+	m.decoder = d
+	return nil
+}
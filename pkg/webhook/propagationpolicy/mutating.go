@@ -0,0 +1,58 @@
+package propagationpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-propagationpolicy,mutating=true,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1,groups=policy.kubeedge.io,resources=propagationpolicies;clusterpropagationpolicies,verbs=create;update,versions=v1alpha1,name=mutate-propagationpolicy.policy.kubeedge.io
+
+// MutatingAdmission sets defaults of PropagationPolicy and ClusterPropagationPolicy objects when creating or updating them.
+type MutatingAdmission struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &MutatingAdmission{}
+var _ admission.DecoderInjector = &MutatingAdmission{}
+
+// Handle patches the policy with its defaults.
+func (m *MutatingAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	klog.V(2).Infof("mutating %s %s for request: %s", req.Kind.Kind, formatRequestObject(req), req.Operation)
+
+	var policy interface{}
+	switch req.Kind.Kind {
+	case "ClusterPropagationPolicy":
+		clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{}
+		if err := m.decoder.Decode(req, clusterPolicy); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		policyv1alpha1.SetDefaultsClusterPropagationPolicy(clusterPolicy)
+		policy = clusterPolicy
+	default:
+		namespacedPolicy := &policyv1alpha1.PropagationPolicy{}
+		if err := m.decoder.Decode(req, namespacedPolicy); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		policyv1alpha1.SetDefaultsPropagationPolicy(namespacedPolicy)
+		policy = namespacedPolicy
+	}
+
+	marshaledBytes, err := json.Marshal(policy)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledBytes)
+}
+
+// InjectDecoder implements admission.DecoderInjector interface.
+// A decoder will be automatically injected.
+func (m *MutatingAdmission) InjectDecoder(d *admission.Decoder) error {
+	m.decoder = d
+	return nil
+}
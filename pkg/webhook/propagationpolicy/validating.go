@@ -0,0 +1,123 @@
+package propagationpolicy
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyv1alpha1 "github.com/Congrool/nodes-grouping/pkg/apis/policy/v1alpha1"
+	"github.com/Congrool/nodes-grouping/pkg/utils"
+	"github.com/Congrool/nodes-grouping/pkg/utils/validation"
+)
+
+// +kubebuilder:webhook:path=/validate-propagationpolicy,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1,groups=policy.kubeedge.io,resources=propagationpolicies;clusterpropagationpolicies,verbs=create;update,versions=v1alpha1,name=validate-propagationpolicy.policy.kubeedge.io
+
+// ValidatingAdmission validates PropagationPolicy and ClusterPropagationPolicy objects when creating or updating them.
+type ValidatingAdmission struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ValidatingAdmission{}
+var _ admission.DecoderInjector = &ValidatingAdmission{}
+
+// Handle denies the policy if it is invalid, and warns about target nodegroups which do not exist.
+// Updates which do not change the spec, or of policies being deleted, are always allowed.
+func (v *ValidatingAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	policy, err := v.decodePolicy(req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	klog.V(2).Infof("validating %s %s for request: %s", req.Kind.Kind, formatRequestObject(req), req.Operation)
+
+	if req.Operation == admissionv1.Update {
+		oldPolicy, err := v.decodeOldPolicy(req)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// updates of metadata or status, such as finalizers added or removed by the controller,
+		// must not be blocked by resource kinds which stopped resolving after the policy is admitted
+		if policy.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldPolicy.Spec, policy.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	allErrs := validation.ValidatePropagationPolicy(policy)
+	allErrs = append(allErrs, validation.ValidateResourceSelectorsResolvable(v.Client.RESTMapper(),
+		policy.Spec.ResourceSelectors, field.NewPath("spec", "resourceSelectors"))...)
+	if len(allErrs) != 0 {
+		return admission.Denied(allErrs.ToAggregate().Error())
+	}
+
+	names := utils.GetTargetNodeGroupNames(policy)
+	if policy.Spec.Spillover.OverflowNodeGroup != "" {
+		names = append(names, policy.Spec.Spillover.OverflowNodeGroup)
+	}
+	warnings, err := validation.GetUnknownNodeGroupWarnings(ctx, v.Client, names)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for _, weight := range policy.Spec.Placement.StaticWeightList {
+		if len(weight.NodeGroupNames) > 1 {
+			warnings = append(warnings, "only the first nodegroup of a weight entry gets pods, others in "+
+				"nodeGroupNames are ignored")
+			break
+		}
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// decodePolicy decodes the policy of the request. ClusterPropagationPolicies are
+// converted to PropagationPolicies without namespace.
+func (v *ValidatingAdmission) decodePolicy(req admission.Request) (*policyv1alpha1.PropagationPolicy, error) {
+	if req.Kind.Kind == "ClusterPropagationPolicy" {
+		clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{}
+		if err := v.decoder.Decode(req, clusterPolicy); err != nil {
+			return nil, err
+		}
+		return utils.ConvertClusterPropagationPolicy(clusterPolicy), nil
+	}
+	policy := &policyv1alpha1.PropagationPolicy{}
+	if err := v.decoder.Decode(req, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// decodeOldPolicy decodes the old policy of the update request.
+func (v *ValidatingAdmission) decodeOldPolicy(req admission.Request) (*policyv1alpha1.PropagationPolicy, error) {
+	if req.Kind.Kind == "ClusterPropagationPolicy" {
+		clusterPolicy := &policyv1alpha1.ClusterPropagationPolicy{}
+		if err := v.decoder.DecodeRaw(req.OldObject, clusterPolicy); err != nil {
+			return nil, err
+		}
+		return utils.ConvertClusterPropagationPolicy(clusterPolicy), nil
+	}
+	policy := &policyv1alpha1.PropagationPolicy{}
+	if err := v.decoder.DecodeRaw(req.OldObject, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// formatRequestObject formats the object of the request as "<namespace>/<name>",
+// or "<name>" if it is cluster-scoped.
+func formatRequestObject(req admission.Request) string {
+	if req.Namespace == "" {
+		return req.Name
+	}
+	return req.Namespace + "/" + req.Name
+}
+
+// InjectDecoder implements admission.DecoderInjector interface.
+// A decoder will be automatically injected.
+func (v *ValidatingAdmission) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}